@@ -0,0 +1,121 @@
+// Package atomic128 provides atomic 128-bit integer types.
+//
+// On amd64, the operations use the CMPXCHG16B instruction if the CPU supports it.
+// On arm64, they use the LDAXP/STLXP exclusive pair instructions.
+// On other architectures, or if the purego build tag is set,
+// they fall back to a set of sharded locks.
+package atomic128
+
+import (
+	"unsafe"
+
+	"github.com/shogo82148/int128"
+)
+
+// Uint128 is an atomic int128.Uint128.
+// The zero value is zero.
+// A Uint128 must not be copied after first use.
+type Uint128 struct {
+	_ noCopy
+
+	// the 128-bit word is v[0:2] or v[1:3], whichever is 16-byte aligned.
+	// The lower half is stored at the lower address.
+	v [3]uint64
+}
+
+// addr returns the 16-byte aligned address of the 128-bit word.
+func (x *Uint128) addr() *[2]uint64 {
+	p := unsafe.Pointer(&x.v)
+	if uintptr(p)&15 != 0 {
+		p = unsafe.Pointer(&x.v[1])
+	}
+	return (*[2]uint64)(p)
+}
+
+// Load atomically loads and returns the value stored in x.
+func (x *Uint128) Load() int128.Uint128 {
+	lo, hi := load(x.addr())
+	return int128.Uint128{H: hi, L: lo}
+}
+
+// Store atomically stores val into x.
+func (x *Uint128) Store(val int128.Uint128) {
+	x.Swap(val)
+}
+
+// Swap atomically stores new into x and returns the previous value.
+func (x *Uint128) Swap(new int128.Uint128) (old int128.Uint128) {
+	addr := x.addr()
+	for {
+		lo, hi := load(addr)
+		if cas(addr, lo, hi, new.L, new.H) {
+			return int128.Uint128{H: hi, L: lo}
+		}
+	}
+}
+
+// CompareAndSwap executes the compare-and-swap operation for x.
+func (x *Uint128) CompareAndSwap(old, new int128.Uint128) (swapped bool) {
+	return cas(x.addr(), old.L, old.H, new.L, new.H)
+}
+
+// Add atomically adds delta to x and returns the new value.
+// The addition wraps around on overflow.
+func (x *Uint128) Add(delta int128.Uint128) (new int128.Uint128) {
+	addr := x.addr()
+	for {
+		lo, hi := load(addr)
+		new = int128.Uint128{H: hi, L: lo}.Add(delta)
+		if cas(addr, lo, hi, new.L, new.H) {
+			return new
+		}
+	}
+}
+
+// Int128 is an atomic int128.Int128.
+// The zero value is zero.
+// An Int128 must not be copied after first use.
+type Int128 struct {
+	_ noCopy
+	v Uint128
+}
+
+// Load atomically loads and returns the value stored in x.
+func (x *Int128) Load() int128.Int128 {
+	return x.v.Load().Int128()
+}
+
+// Store atomically stores val into x.
+func (x *Int128) Store(val int128.Int128) {
+	x.v.Store(val.Uint128())
+}
+
+// Swap atomically stores new into x and returns the previous value.
+func (x *Int128) Swap(new int128.Int128) (old int128.Int128) {
+	return x.v.Swap(new.Uint128()).Int128()
+}
+
+// CompareAndSwap executes the compare-and-swap operation for x.
+func (x *Int128) CompareAndSwap(old, new int128.Int128) (swapped bool) {
+	return x.v.CompareAndSwap(old.Uint128(), new.Uint128())
+}
+
+// Add atomically adds delta to x and returns the new value.
+// The addition wraps around on overflow.
+func (x *Int128) Add(delta int128.Int128) (new int128.Int128) {
+	// the two's complement addition is the same as the unsigned one.
+	return x.v.Add(delta.Uint128()).Int128()
+}
+
+// noCopy may be added to structs which must not be copied
+// after the first use.
+//
+// See https://golang.org/issues/8005#issuecomment-190753527
+// for details.
+//
+// Note that it must not be embedded, due to the Lock and Unlock methods.
+type noCopy struct{}
+
+// Lock is a no-op used by -copylocks checker from `go vet`.
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
@@ -0,0 +1,28 @@
+//go:build !purego
+
+package atomic128
+
+// hasCX16 reports whether the CPU supports the CMPXCHG16B instruction.
+var hasCX16 = cpuHasCX16()
+
+func cpuHasCX16() bool
+
+//go:noescape
+func load128(addr *[2]uint64) (lo, hi uint64)
+
+//go:noescape
+func cas128(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool)
+
+func load(addr *[2]uint64) (lo, hi uint64) {
+	if hasCX16 {
+		return load128(addr)
+	}
+	return loadLocked(addr)
+}
+
+func cas(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool) {
+	if hasCX16 {
+		return cas128(addr, oldLo, oldHi, newLo, newHi)
+	}
+	return casLocked(addr, oldLo, oldHi, newLo, newHi)
+}
@@ -0,0 +1,40 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// func cpuHasCX16() bool
+TEXT ·cpuHasCX16(SB), NOSPLIT, $0-1
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	SHRL $13, CX
+	ANDL $1, CX
+	MOVB CX, ret+0(FP)
+	RET
+
+// func load128(addr *[2]uint64) (lo, hi uint64)
+TEXT ·load128(SB), NOSPLIT, $0-24
+	MOVQ addr+0(FP), DI
+	// CMPXCHG16B with the same old and new value
+	// doesn't change the memory and loads the current value into DX:AX.
+	XORQ AX, AX
+	XORQ DX, DX
+	XORQ BX, BX
+	XORQ CX, CX
+	LOCK
+	CMPXCHG16B (DI)
+	MOVQ AX, lo+8(FP)
+	MOVQ DX, hi+16(FP)
+	RET
+
+// func cas128(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool)
+TEXT ·cas128(SB), NOSPLIT, $0-41
+	MOVQ addr+0(FP), DI
+	MOVQ oldLo+8(FP), AX
+	MOVQ oldHi+16(FP), DX
+	MOVQ newLo+24(FP), BX
+	MOVQ newHi+32(FP), CX
+	LOCK
+	CMPXCHG16B (DI)
+	SETEQ swapped+40(FP)
+	RET
@@ -0,0 +1,17 @@
+//go:build !purego
+
+package atomic128
+
+//go:noescape
+func load128(addr *[2]uint64) (lo, hi uint64)
+
+//go:noescape
+func cas128(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool)
+
+func load(addr *[2]uint64) (lo, hi uint64) {
+	return load128(addr)
+}
+
+func cas(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool) {
+	return cas128(addr, oldLo, oldHi, newLo, newHi)
+}
@@ -0,0 +1,42 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// func load128(addr *[2]uint64) (lo, hi uint64)
+TEXT ·load128(SB), NOSPLIT, $0-24
+	MOVD addr+0(FP), R0
+load_again:
+	// LDAXP alone isn't single-copy atomic.
+	// the load is atomic only if the following store succeeds.
+	LDAXP (R0), (R1, R2)
+	STLXP (R1, R2), (R0), R3
+	CBNZ  R3, load_again
+	MOVD  R1, lo+8(FP)
+	MOVD  R2, hi+16(FP)
+	RET
+
+// func cas128(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool)
+TEXT ·cas128(SB), NOSPLIT, $0-41
+	MOVD addr+0(FP), R0
+	MOVD oldLo+8(FP), R1
+	MOVD oldHi+16(FP), R2
+	MOVD newLo+24(FP), R3
+	MOVD newHi+32(FP), R4
+cas_again:
+	LDAXP (R0), (R5, R6)
+	CMP   R1, R5
+	BNE   cas_fail
+	CMP   R2, R6
+	BNE   cas_fail
+	STLXP (R3, R4), (R0), R7
+	CBNZ  R7, cas_again
+	MOVD  $1, R8
+	MOVB  R8, swapped+40(FP)
+	RET
+
+cas_fail:
+	// write back the current value to release the exclusive monitor.
+	STLXP (R5, R6), (R0), R7
+	CBNZ  R7, cas_again
+	MOVB  ZR, swapped+40(FP)
+	RET
@@ -0,0 +1,11 @@
+//go:build (!amd64 && !arm64) || purego
+
+package atomic128
+
+func load(addr *[2]uint64) (lo, hi uint64) {
+	return loadLocked(addr)
+}
+
+func cas(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool) {
+	return casLocked(addr, oldLo, oldHi, newLo, newHi)
+}
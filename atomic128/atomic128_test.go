@@ -0,0 +1,176 @@
+package atomic128
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/shogo82148/int128"
+)
+
+func TestUint128(t *testing.T) {
+	var x Uint128
+	if got := x.Load(); got != (int128.Uint128{}) {
+		t.Errorf("zero value should be 0, but %#v", got)
+	}
+
+	v1 := int128.Uint128{H: 0x0123_4567_89ab_cdef, L: 0xfedc_ba98_7654_3210}
+	x.Store(v1)
+	if got := x.Load(); got != v1 {
+		t.Errorf("Load() should %#v, but %#v", v1, got)
+	}
+
+	v2 := int128.Uint128{H: 1, L: 2}
+	if got := x.Swap(v2); got != v1 {
+		t.Errorf("Swap() should return %#v, but %#v", v1, got)
+	}
+	if got := x.Load(); got != v2 {
+		t.Errorf("Load() should %#v, but %#v", v2, got)
+	}
+
+	if x.CompareAndSwap(v1, v2) {
+		t.Error("CompareAndSwap() should fail")
+	}
+	if !x.CompareAndSwap(v2, v1) {
+		t.Error("CompareAndSwap() should succeed")
+	}
+	if got := x.Load(); got != v1 {
+		t.Errorf("Load() should %#v, but %#v", v1, got)
+	}
+
+	// only the upper half differs.
+	if x.CompareAndSwap(int128.Uint128{H: 0, L: v1.L}, v2) {
+		t.Error("CompareAndSwap() should fail")
+	}
+
+	x.Store(int128.Uint128{H: 0, L: 0xffff_ffff_ffff_ffff})
+	if got, want := x.Add(int128.Uint128{H: 0, L: 1}), (int128.Uint128{H: 1, L: 0}); got != want {
+		t.Errorf("Add() should %#v, but %#v", want, got)
+	}
+}
+
+func TestInt128(t *testing.T) {
+	var x Int128
+	minusOne := int128.Int128{H: -1, L: 0xffff_ffff_ffff_ffff}
+	if got := x.Add(minusOne); got != minusOne {
+		t.Errorf("Add() should %#v, but %#v", minusOne, got)
+	}
+	if got, want := x.Add(int128.Int128{H: 0, L: 2}), (int128.Int128{H: 0, L: 1}); got != want {
+		t.Errorf("Add() should %#v, but %#v", want, got)
+	}
+	if got, want := x.Swap(minusOne), (int128.Int128{H: 0, L: 1}); got != want {
+		t.Errorf("Swap() should return %#v, but %#v", want, got)
+	}
+	if !x.CompareAndSwap(minusOne, int128.Int128{}) {
+		t.Error("CompareAndSwap() should succeed")
+	}
+	x.Store(minusOne)
+	if got := x.Load(); got != minusOne {
+		t.Errorf("Load() should %#v, but %#v", minusOne, got)
+	}
+}
+
+func TestUint128_Alignment(t *testing.T) {
+	// the 128-bit word must be 16-byte aligned wherever Uint128 is placed.
+	var s struct {
+		a byte
+		x [4]Uint128
+	}
+	for i := range s.x {
+		s.x[i].Store(int128.Uint128{H: uint64(i), L: uint64(i)})
+		if got, want := s.x[i].Load(), (int128.Uint128{H: uint64(i), L: uint64(i)}); got != want {
+			t.Errorf("%d: Load() should %#v, but %#v", i, want, got)
+		}
+	}
+}
+
+func TestUint128_AddConcurrent(t *testing.T) {
+	const goroutines = 8
+	const count = 10000
+
+	// start near 2**64 to check the carry from the lower half.
+	start := int128.Uint128{H: 0, L: 0xffff_ffff_ffff_ffff - goroutines*count/2}
+	var x Uint128
+	x.Store(start)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				x.Add(int128.Uint128{H: 0, L: 1})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := x.Load(), start.Add(int128.Uint128{H: 0, L: goroutines * count}); got != want {
+		t.Errorf("Load() should %#v, but %#v", want, got)
+	}
+}
+
+func TestUint128_CompareAndSwapConcurrent(t *testing.T) {
+	const goroutines = 8
+	const count = 1000
+
+	// each goroutine increments x with its own CAS loop,
+	// and a torn read would make the total wrong.
+	var x Uint128
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				for {
+					old := x.Load()
+					// keep both halves equal.
+					new := int128.Uint128{H: old.H + 1, L: old.L + 1}
+					if x.CompareAndSwap(old, new) {
+						break
+					}
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	// concurrent readers never see a torn value.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			v := x.Load()
+			if v.H != v.L {
+				t.Errorf("torn read: %#v", v)
+				return
+			}
+			if v.H == goroutines*count {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	<-done
+	if got, want := x.Load(), (int128.Uint128{H: goroutines * count, L: goroutines * count}); got != want {
+		t.Errorf("Load() should %#v, but %#v", want, got)
+	}
+}
+
+func BenchmarkUint128_Add(b *testing.B) {
+	var x Uint128
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			x.Add(int128.Uint128{H: 0, L: 1})
+		}
+	})
+}
+
+func BenchmarkUint128_Load(b *testing.B) {
+	var x Uint128
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(x.Load())
+	}
+}
@@ -0,0 +1,122 @@
+package atomic128
+
+import (
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/shogo82148/int128"
+)
+
+// Counter128 is a 128-bit counter for heavily concurrent updates.
+// It spreads the additions across several shards to avoid contention,
+// and merges them on Load.
+// The zero value is a counter of zero.
+// A Counter128 must not be copied after first use.
+//
+// Each shard has its own lock, and Add holds only one of them, so the additions rarely contend.
+// Load and Reset hold all the locks at once, so they observe a snapshot:
+// the value they return is the sum of the additions that completed before them,
+// and the concurrent additions are observed as a whole or not at all.
+type Counter128 struct {
+	_      noCopy
+	once   sync.Once
+	shards []counterShard
+}
+
+type counterShard struct {
+	mu sync.Mutex
+	v  int128.Uint128
+	_  [cacheLineSize - unsafe.Sizeof(sync.Mutex{}) - unsafe.Sizeof(int128.Uint128{})]byte
+}
+
+// hintPool caches the shard hint of each P.
+// sync.Pool keeps its items per P, so the goroutines running on
+// the same P tend to use the same shard.
+var hintPool = sync.Pool{
+	New: func() interface{} {
+		// the hint must not be zero, because zero is a fixed point of xorshift.
+		h := atomic.AddUint32(&hintSeed, 0x9e3779b9) | 1
+		return &h
+	},
+}
+
+var hintSeed uint32
+
+func (c *Counter128) init() {
+	n := runtime.GOMAXPROCS(0)
+	// round up to a power of two
+	n = 1 << bits.Len(uint(n-1))
+	c.shards = make([]counterShard, n)
+}
+
+// Add atomically adds delta to c.
+// The addition wraps around on overflow.
+func (c *Counter128) Add(delta int128.Uint128) {
+	c.once.Do(c.init)
+	mask := uint32(len(c.shards) - 1)
+
+	h := hintPool.Get().(*uint32)
+	s := &c.shards[*h&mask]
+	for i := 0; !s.mu.TryLock(); i++ {
+		if i >= len(c.shards) {
+			// Load or Reset may hold all the shards. wait for them.
+			s.mu.Lock()
+			break
+		}
+
+		// the shard is contended. move to another one.
+		*h ^= *h << 13
+		*h ^= *h >> 17
+		*h ^= *h << 5
+		s = &c.shards[*h&mask]
+	}
+	s.v = s.v.Add(delta)
+	s.mu.Unlock()
+	hintPool.Put(h)
+}
+
+// Inc atomically increments c by one.
+func (c *Counter128) Inc() {
+	c.Add(int128.Uint128{H: 0, L: 1})
+}
+
+// Load returns the current value of c.
+func (c *Counter128) Load() int128.Uint128 {
+	c.once.Do(c.init)
+	c.lockAll()
+	var sum int128.Uint128
+	for i := range c.shards {
+		sum = sum.Add(c.shards[i].v)
+	}
+	c.unlockAll()
+	return sum
+}
+
+// Reset sets c to zero, and returns the value before the reset.
+func (c *Counter128) Reset() (old int128.Uint128) {
+	c.once.Do(c.init)
+	c.lockAll()
+	for i := range c.shards {
+		old = old.Add(c.shards[i].v)
+		c.shards[i].v = int128.Uint128{}
+	}
+	c.unlockAll()
+	return old
+}
+
+// lockAll locks all the shards.
+// The shards are always locked in the same order, so concurrent Loads and Resets don't deadlock.
+func (c *Counter128) lockAll() {
+	for i := range c.shards {
+		c.shards[i].mu.Lock()
+	}
+}
+
+func (c *Counter128) unlockAll() {
+	for i := range c.shards {
+		c.shards[i].mu.Unlock()
+	}
+}
@@ -0,0 +1,170 @@
+package atomic128
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shogo82148/int128"
+)
+
+func TestCounter128(t *testing.T) {
+	var c Counter128
+	if got := c.Load(); got != (int128.Uint128{}) {
+		t.Errorf("zero value should be 0, but %#v", got)
+	}
+
+	c.Inc()
+	c.Add(int128.Uint128{H: 0, L: 0xffff_ffff_ffff_ffff})
+	if got, want := c.Load(), (int128.Uint128{H: 1, L: 0}); got != want {
+		t.Errorf("Load() should %#v, but %#v", want, got)
+	}
+
+	if got, want := c.Reset(), (int128.Uint128{H: 1, L: 0}); got != want {
+		t.Errorf("Reset() should return %#v, but %#v", want, got)
+	}
+	if got := c.Load(); got != (int128.Uint128{}) {
+		t.Errorf("Load() should be 0 after Reset(), but %#v", got)
+	}
+}
+
+func TestCounter128_Concurrent(t *testing.T) {
+	const goroutines = 16
+	const count = 10000
+
+	var c Counter128
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var reset int128.Uint128
+
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				// the shards overflow the lower half many times.
+				c.Add(int128.Uint128{H: 0, L: 1 << 62})
+				if i == 0 && j%1000 == 0 {
+					// no addition is lost by Reset.
+					old := c.Reset()
+					mu.Lock()
+					reset = reset.Add(old)
+					mu.Unlock()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got := c.Load().Add(reset)
+	want := int128.Uint128{H: 0, L: goroutines * count}.Lsh(62)
+	if got != want {
+		t.Errorf("total should %#v, but %#v", want, got)
+	}
+}
+
+func TestCounter128_Snapshot(t *testing.T) {
+	// use several shards even on a single CPU.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	const goroutines = 8
+	var c Counter128
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				// each goroutine contributes 0 or 1 to the total at any moment,
+				// but the additions may go to different shards.
+				c.Inc()
+				c.Add(int128.Uint128{H: 0xffff_ffff_ffff_ffff, L: 0xffff_ffff_ffff_ffff}) // -1
+			}
+		}()
+	}
+
+	max := int128.Uint128{H: 0, L: goroutines}
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if got := c.Load(); got.Cmp(max) > 0 {
+			t.Errorf("Load() returned %#v, which the counter never had", got)
+			break
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	if got := c.Load(); got != (int128.Uint128{}) {
+		t.Errorf("total should be 0, but %#v", got)
+	}
+}
+
+func TestCounter128_AddWaitsForLoad(t *testing.T) {
+	var c Counter128
+	c.Inc()
+
+	// hold all the shards like Load does in the middle of the summation.
+	c.lockAll()
+	done := make(chan struct{})
+	go func() {
+		c.Inc()
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Add should wait until all the shards are unlocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	c.unlockAll()
+	<-done
+
+	if got, want := c.Load(), (int128.Uint128{H: 0, L: 2}); got != want {
+		t.Errorf("Load() should %#v, but %#v", want, got)
+	}
+}
+
+func BenchmarkCounter128_Inc(b *testing.B) {
+	for _, procs := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			var c Counter128
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					c.Inc()
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkUint128_Inc is the baseline of BenchmarkCounter128_Inc.
+func BenchmarkUint128_Inc(b *testing.B) {
+	for _, procs := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			var x Uint128
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					x.Add(int128.Uint128{H: 0, L: 1})
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkCounter128_Load(b *testing.B) {
+	var c Counter128
+	c.Inc()
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(c.Load())
+	}
+}
@@ -0,0 +1,40 @@
+package atomic128
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// cacheLineSize is used to prevent false sharing between the locks.
+const cacheLineSize = 64
+
+// locks is the set of locks for the platforms that don't support 128-bit atomic operations.
+var locks [61]struct {
+	sync.Mutex
+	_ [cacheLineSize - unsafe.Sizeof(sync.Mutex{})]byte
+}
+
+// lockFor returns the lock that guards addr.
+func lockFor(addr *[2]uint64) *sync.Mutex {
+	// addr is 16-byte aligned, so the lower 4 bits are always zero.
+	return &locks[(uintptr(unsafe.Pointer(addr))>>4)%uintptr(len(locks))].Mutex
+}
+
+func loadLocked(addr *[2]uint64) (lo, hi uint64) {
+	mu := lockFor(addr)
+	mu.Lock()
+	lo, hi = addr[0], addr[1]
+	mu.Unlock()
+	return
+}
+
+func casLocked(addr *[2]uint64, oldLo, oldHi, newLo, newHi uint64) (swapped bool) {
+	mu := lockFor(addr)
+	mu.Lock()
+	if addr[0] == oldLo && addr[1] == oldHi {
+		addr[0], addr[1] = newLo, newHi
+		swapped = true
+	}
+	mu.Unlock()
+	return
+}
@@ -0,0 +1,213 @@
+package int128
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// CBOR major types and tags defined in RFC 8949.
+const (
+	cborUnsigned   = 0
+	cborNegative   = 1
+	cborByteString = 2
+	cborTag        = 6
+
+	cborTagPositiveBignum = 2
+	cborTagNegativeBignum = 3
+)
+
+var (
+	errCBORSyntax       = errors.New("int128: invalid CBOR data")
+	errCBORType         = errors.New("int128: CBOR data item is not an integer")
+	errCBORNonCanonical = errors.New("int128: non-canonical CBOR encoding")
+	errCBORRange        = errors.New("int128: CBOR integer out of range")
+)
+
+// MarshalCBOR implements the Marshaler interface of CBOR libraries such as github.com/fxamacker/cbor.
+// a is encoded as an unsigned integer (major type 0) if it fits in 64 bits,
+// and as a positive bignum (tag 2) otherwise.
+func (a Uint128) MarshalCBOR() ([]byte, error) {
+	return appendCBOR(nil, false, a), nil
+}
+
+// UnmarshalCBOR implements the Unmarshaler interface of CBOR libraries such as github.com/fxamacker/cbor.
+// It accepts only the preferred serialization of RFC 8949:
+// the arguments must be minimal, and bignums must not have leading zeros nor fit in 64 bits.
+func (a *Uint128) UnmarshalCBOR(data []byte) error {
+	neg, v, err := parseCBOR(data)
+	if err != nil {
+		return err
+	}
+	if neg {
+		return errCBORRange
+	}
+	*a = v
+	return nil
+}
+
+// MarshalCBOR implements the Marshaler interface of CBOR libraries such as github.com/fxamacker/cbor.
+// a is encoded as an integer (major type 0 or 1) if it fits in 64 bits,
+// and as a bignum (tag 2 or 3) otherwise.
+func (a Int128) MarshalCBOR() ([]byte, error) {
+	if a.H < 0 {
+		// CBOR encodes the negative integer a as -1-a = ^a.
+		return appendCBOR(nil, true, a.Not().Uint128()), nil
+	}
+	return appendCBOR(nil, false, a.Uint128()), nil
+}
+
+// UnmarshalCBOR implements the Unmarshaler interface of CBOR libraries such as github.com/fxamacker/cbor.
+// It accepts only the preferred serialization of RFC 8949:
+// the arguments must be minimal, and bignums must not have leading zeros nor fit in 64 bits.
+func (a *Int128) UnmarshalCBOR(data []byte) error {
+	neg, v, err := parseCBOR(data)
+	if err != nil {
+		return err
+	}
+	if v.H >= 1<<63 {
+		return errCBORRange
+	}
+	if neg {
+		v = v.Not()
+	}
+	*a = v.Int128()
+	return nil
+}
+
+// appendCBOR appends the CBOR encoding of the integer to dst.
+// If neg is true, the integer is -1-v; otherwise it is v.
+func appendCBOR(dst []byte, neg bool, v Uint128) []byte {
+	if v.H == 0 {
+		major := byte(cborUnsigned)
+		if neg {
+			major = cborNegative
+		}
+		return appendCBORHead(dst, major, v.L)
+	}
+
+	tag := uint64(cborTagPositiveBignum)
+	if neg {
+		tag = cborTagNegativeBignum
+	}
+	dst = appendCBORHead(dst, cborTag, tag)
+
+	// the big-endian bytes without leading zeros.
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], v.H)
+	binary.BigEndian.PutUint64(buf[8:], v.L)
+	n := bits.LeadingZeros64(v.H) / 8
+	dst = appendCBORHead(dst, cborByteString, uint64(16-n))
+	return append(dst, buf[n:]...)
+}
+
+// appendCBORHead appends the head of a data item with the shortest argument.
+func appendCBORHead(dst []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(dst, major|byte(arg))
+	case arg <= 0xff:
+		return append(dst, major|24, byte(arg))
+	case arg <= 0xffff:
+		return append(dst, major|25, byte(arg>>8), byte(arg))
+	case arg <= 0xffff_ffff:
+		return append(dst, major|26, byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	default:
+		return append(dst, major|27,
+			byte(arg>>56), byte(arg>>48), byte(arg>>40), byte(arg>>32),
+			byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	}
+}
+
+// parseCBOR parses the CBOR encoded integer in data.
+// If neg is true, the integer is -1-v; otherwise it is v.
+func parseCBOR(data []byte) (neg bool, v Uint128, err error) {
+	major, arg, rest, err := parseCBORHead(data)
+	if err != nil {
+		return false, Uint128{}, err
+	}
+
+	switch major {
+	case cborUnsigned, cborNegative:
+		if len(rest) != 0 {
+			return false, Uint128{}, errCBORSyntax
+		}
+		return major == cborNegative, Uint128{0, arg}, nil
+	case cborTag:
+		if arg != cborTagPositiveBignum && arg != cborTagNegativeBignum {
+			return false, Uint128{}, errCBORType
+		}
+		neg = arg == cborTagNegativeBignum
+	default:
+		return false, Uint128{}, errCBORType
+	}
+
+	// the content of a bignum is a byte string.
+	major, arg, rest, err = parseCBORHead(rest)
+	if err != nil {
+		return false, Uint128{}, err
+	}
+	if major != cborByteString {
+		return false, Uint128{}, errCBORSyntax
+	}
+	if arg != uint64(len(rest)) {
+		return false, Uint128{}, errCBORSyntax
+	}
+	if len(rest) > 16 {
+		return false, Uint128{}, errCBORRange
+	}
+	if len(rest) <= 8 || rest[0] == 0 {
+		// the bignums that fit in 64 bits must be encoded as integers,
+		// and leading zeros are not allowed.
+		return false, Uint128{}, errCBORNonCanonical
+	}
+
+	var buf [16]byte
+	copy(buf[16-len(rest):], rest)
+	v = Uint128{binary.BigEndian.Uint64(buf[:8]), binary.BigEndian.Uint64(buf[8:])}
+	return neg, v, nil
+}
+
+// parseCBORHead parses the head of a data item, and returns the major type, the argument, and the rest of data.
+// It rejects the arguments that are not encoded in the shortest form, and indefinite lengths.
+func parseCBORHead(data []byte) (major byte, arg uint64, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, nil, errCBORSyntax
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+
+	var min uint64
+	switch {
+	case info < 24:
+		return major, uint64(info), data, nil
+	case info == 24:
+		if len(data) < 1 {
+			return 0, 0, nil, errCBORSyntax
+		}
+		arg, data, min = uint64(data[0]), data[1:], 24
+	case info == 25:
+		if len(data) < 2 {
+			return 0, 0, nil, errCBORSyntax
+		}
+		arg, data, min = uint64(binary.BigEndian.Uint16(data)), data[2:], 0xff+1
+	case info == 26:
+		if len(data) < 4 {
+			return 0, 0, nil, errCBORSyntax
+		}
+		arg, data, min = uint64(binary.BigEndian.Uint32(data)), data[4:], 0xffff+1
+	case info == 27:
+		if len(data) < 8 {
+			return 0, 0, nil, errCBORSyntax
+		}
+		arg, data, min = binary.BigEndian.Uint64(data), data[8:], 0xffff_ffff+1
+	default:
+		// reserved values and indefinite lengths
+		return 0, 0, nil, errCBORSyntax
+	}
+	if arg < min {
+		return 0, 0, nil, errCBORNonCanonical
+	}
+	return major, arg, data, nil
+}
@@ -0,0 +1,238 @@
+package int128
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+func TestUint128_MarshalCBOR(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		want string
+	}{
+		// the examples from RFC 8949 Appendix A.
+		{Uint128{0, 0}, "00"},
+		{Uint128{0, 1}, "01"},
+		{Uint128{0, 10}, "0a"},
+		{Uint128{0, 23}, "17"},
+		{Uint128{0, 24}, "1818"},
+		{Uint128{0, 25}, "1819"},
+		{Uint128{0, 100}, "1864"},
+		{Uint128{0, 1000}, "1903e8"},
+		{Uint128{0, 1000000}, "1a000f4240"},
+		{Uint128{0, 1000000000000}, "1b000000e8d4a51000"},
+		{Uint128{0, math.MaxUint64}, "1bffffffffffffffff"},
+		{Uint128{1, 0}, "c249010000000000000000"},
+
+		{Uint128{0x0102, 0x0304_0506_0708_090a}, "c24a0102030405060708090a"},
+		{Uint128{math.MaxUint64, math.MaxUint64}, "c250ffffffffffffffffffffffffffffffff"},
+	}
+
+	for i, tc := range testCases {
+		got, err := tc.a.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Errorf("%d: %#v should %s, but %x", i, tc.a, tc.want, got)
+		}
+
+		data, _ := hex.DecodeString(tc.want)
+		var a Uint128
+		if err := a.UnmarshalCBOR(data); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if a != tc.a {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.want, tc.a, a)
+		}
+	}
+}
+
+func TestInt128_MarshalCBOR(t *testing.T) {
+	testCases := []struct {
+		a    Int128
+		want string
+	}{
+		// the examples from RFC 8949 Appendix A.
+		{Int128{0, 0}, "00"},
+		{Int128{0, 1000000}, "1a000f4240"},
+		{Int128{0, math.MaxUint64}, "1bffffffffffffffff"},
+		{Int128{1, 0}, "c249010000000000000000"},
+		{Int128{-1, 0}, "3bffffffffffffffff"},
+		{Int128{-2, math.MaxUint64}, "c349010000000000000000"},
+		{Int128{-1, math.MaxUint64}, "20"},
+		{Int128{-1, math.MaxUint64 - 9}, "29"},
+		{Int128{-1, math.MaxUint64 - 99}, "3863"},
+		{Int128{-1, math.MaxUint64 - 999}, "3903e7"},
+
+		{Int128{math.MaxInt64, math.MaxUint64}, "c2507fffffffffffffffffffffffffffffff"},
+		{Int128{math.MinInt64, 0}, "c3507fffffffffffffffffffffffffffffff"},
+	}
+
+	for i, tc := range testCases {
+		got, err := tc.a.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Errorf("%d: %#v should %s, but %x", i, tc.a, tc.want, got)
+		}
+
+		data, _ := hex.DecodeString(tc.want)
+		var a Int128
+		if err := a.UnmarshalCBOR(data); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if a != tc.a {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.want, tc.a, a)
+		}
+	}
+}
+
+func TestUnmarshalCBOR_Error(t *testing.T) {
+	testCases := []struct {
+		data string
+		err  error
+	}{
+		{data: "", err: errCBORSyntax},
+		{data: "0000", err: errCBORSyntax},   // trailing data
+		{data: "18", err: errCBORSyntax},     // truncated
+		{data: "1b0000", err: errCBORSyntax}, // truncated
+		{data: "1c", err: errCBORSyntax},     // reserved
+		{data: "1f", err: errCBORSyntax},     // indefinite length
+
+		// non-minimal arguments
+		{data: "1817", err: errCBORNonCanonical},
+		{data: "1900ff", err: errCBORNonCanonical},
+		{data: "1a0000ffff", err: errCBORNonCanonical},
+		{data: "1b00000000ffffffff", err: errCBORNonCanonical},
+		{data: "3817", err: errCBORNonCanonical},
+		{data: "d802490100000000000000", err: errCBORNonCanonical},
+
+		// not integers
+		{data: "40", err: errCBORType},
+		{data: "6161", err: errCBORType},
+		{data: "f4", err: errCBORType},
+		{data: "c11a514b67b0", err: errCBORType},
+
+		// non-canonical bignums
+		{data: "c240", err: errCBORNonCanonical},
+		{data: "c24101", err: errCBORNonCanonical},
+		{data: "c248ffffffffffffffff", err: errCBORNonCanonical},
+		{data: "c24a00010000000000000000", err: errCBORNonCanonical},
+		{data: "c34101", err: errCBORNonCanonical},
+
+		// malformed bignums
+		{data: "c2", err: errCBORSyntax},
+		{data: "c201", err: errCBORSyntax},
+		{data: "c24901000000000000000000", err: errCBORSyntax}, // trailing data
+		{data: "c249010000000000000000" + "00", err: errCBORSyntax},
+		{data: "c2490100", err: errCBORSyntax},
+		{data: "c25f41014100ff", err: errCBORSyntax}, // indefinite length
+
+		// oversized bignums
+		{data: "c2510100000000000000000000000000000000", err: errCBORRange},
+		{data: "c3510100000000000000000000000000000000", err: errCBORRange},
+	}
+
+	for i, tc := range testCases {
+		data, err := hex.DecodeString(tc.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u Uint128
+		if err := u.UnmarshalCBOR(data); err != tc.err {
+			t.Errorf("%d: Uint128.UnmarshalCBOR(%s) should %v, but %v", i, tc.data, tc.err, err)
+		}
+		var s Int128
+		if err := s.UnmarshalCBOR(data); err != tc.err {
+			t.Errorf("%d: Int128.UnmarshalCBOR(%s) should %v, but %v", i, tc.data, tc.err, err)
+		}
+	}
+}
+
+func TestUnmarshalCBOR_OutOfRange(t *testing.T) {
+	// negative values for Uint128
+	for _, s := range []string{"20", "3bffffffffffffffff", "c349010000000000000000"} {
+		data, _ := hex.DecodeString(s)
+		var u Uint128
+		if err := u.UnmarshalCBOR(data); err != errCBORRange {
+			t.Errorf("Uint128.UnmarshalCBOR(%s) should %v, but %v", s, errCBORRange, err)
+		}
+	}
+
+	// too large values for Int128
+	for _, s := range []string{"c25080000000000000000000000000000000", "c35080000000000000000000000000000000"} {
+		data, _ := hex.DecodeString(s)
+		var a Int128
+		if err := a.UnmarshalCBOR(data); err != errCBORRange {
+			t.Errorf("Int128.UnmarshalCBOR(%s) should %v, but %v", s, errCBORRange, err)
+		}
+	}
+}
+
+func TestUint128_CBORQuick(t *testing.T) {
+	f := func(a Uint128, shift uint8) bool {
+		a = a.Rsh(uint(shift % 128))
+		data, err := a.MarshalCBOR()
+		if err != nil {
+			return false
+		}
+		var b Uint128
+		if err := b.UnmarshalCBOR(data); err != nil {
+			return false
+		}
+		return a == b
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_CBORQuick(t *testing.T) {
+	f := func(a Int128, shift uint8) bool {
+		a = a.Rsh(uint(shift % 128))
+		data, err := a.MarshalCBOR()
+		if err != nil {
+			return false
+		}
+		var b Int128
+		if err := b.UnmarshalCBOR(data); err != nil {
+			return false
+		}
+		return a == b
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzUnmarshalCBOR(f *testing.F) {
+	f.Add([]byte{0x00})
+	f.Add([]byte{0xc2, 0x49, 0x01, 0, 0, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{0xc3, 0x49, 0x01, 0, 0, 0, 0, 0, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// accepted encodings must be canonical.
+		var u Uint128
+		if err := u.UnmarshalCBOR(data); err == nil {
+			got, _ := u.MarshalCBOR()
+			if string(got) != string(data) {
+				t.Errorf("%x is decoded into %#v, but it is encoded into %x", data, u, got)
+			}
+		}
+		var s Int128
+		if err := s.UnmarshalCBOR(data); err == nil {
+			got, _ := s.MarshalCBOR()
+			if string(got) != string(data) {
+				t.Errorf("%x is decoded into %#v, but it is encoded into %x", data, s, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,239 @@
+// Package column encodes arrays of 128-bit integers in a compact columnar format.
+//
+// The values are split into blocks of at most BlockSize values.
+// Each block stores its first value as is, and the differences between
+// consecutive values (deltas) are zigzag-encoded, subtracted by the minimum
+// of them (frame of reference), and bit-packed with the smallest width
+// that fits all of them.
+// Sorted sequences, counters, and slowly changing IDs need only a few bits per value.
+//
+// Every block starts with a fixed-size header, so the blocks can be located
+// without decoding their payloads; see [Index] for random access.
+//
+// Int128 and Uint128 values share the same encoding; the stream doesn't record which one was written.
+package column
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/shogo82148/int128"
+)
+
+// BlockSize is the maximum number of values in a block.
+const BlockSize = 128
+
+// magic is the header of the stream. The last byte is the version.
+const magic = "i128c\x01"
+
+// headerSize is the size of the block header:
+//
+//	count - 1 (1 byte)
+//	width     (1 byte)
+//	first     (16 bytes, big endian)
+//	reference (16 bytes, big endian)
+const headerSize = 1 + 1 + 16 + 16
+
+var (
+	errMagic     = errors.New("column: invalid stream header")
+	errCorrupted = errors.New("column: corrupted block")
+
+	errNegativeOffset = errors.New("column: negative offset")
+)
+
+// blockHeader is the decoded header of a block.
+type blockHeader struct {
+	count int
+	width uint
+	first int128.Uint128
+	ref   int128.Uint128
+}
+
+// payloadLen returns the length of the bit-packed deltas that follow the header.
+func (h blockHeader) payloadLen() int {
+	return int((uint(h.count-1)*h.width + 7) / 8)
+}
+
+func (h blockHeader) put(b []byte) {
+	b[0] = byte(h.count - 1)
+	b[1] = byte(h.width)
+	binary.BigEndian.PutUint64(b[2:], h.first.H)
+	binary.BigEndian.PutUint64(b[10:], h.first.L)
+	binary.BigEndian.PutUint64(b[18:], h.ref.H)
+	binary.BigEndian.PutUint64(b[26:], h.ref.L)
+}
+
+func parseHeader(b []byte) (blockHeader, error) {
+	h := blockHeader{
+		count: int(b[0]) + 1,
+		width: uint(b[1]),
+		first: int128.Uint128{H: binary.BigEndian.Uint64(b[2:]), L: binary.BigEndian.Uint64(b[10:])},
+		ref:   int128.Uint128{H: binary.BigEndian.Uint64(b[18:]), L: binary.BigEndian.Uint64(b[26:])},
+	}
+	if h.count > BlockSize || h.width > 128 {
+		return blockHeader{}, errCorrupted
+	}
+	return h, nil
+}
+
+// zigzag maps the signed delta d to an unsigned integer,
+// so that the deltas close to zero become small.
+func zigzag(d int128.Uint128) int128.Uint128 {
+	sign := d.Int128().Rsh(127).Uint128()
+	return d.Lsh(1).Xor(sign)
+}
+
+// unzigzag is the inverse of zigzag.
+func unzigzag(z int128.Uint128) int128.Uint128 {
+	sign := int128.Uint128{H: 0, L: z.L & 1}.Neg()
+	return z.Rsh(1).Xor(sign)
+}
+
+// encodeBlock appends the encoded block of values to dst.
+// values must have 1 to BlockSize elements.
+func encodeBlock(dst []byte, values []int128.Uint128, deltas []int128.Uint128) []byte {
+	deltas = deltas[:0]
+	prev := values[0]
+	for _, v := range values[1:] {
+		deltas = append(deltas, zigzag(v.Sub(prev)))
+		prev = v
+	}
+
+	// frame of reference
+	var ref, or int128.Uint128
+	if len(deltas) > 0 {
+		ref = int128.Min(deltas[0], deltas[1:]...)
+	}
+	for i, d := range deltas {
+		deltas[i] = d.Sub(ref)
+		or = or.Or(deltas[i])
+	}
+
+	h := blockHeader{
+		count: len(values),
+		width: uint(or.Len()),
+		first: values[0],
+		ref:   ref,
+	}
+	var buf [headerSize]byte
+	h.put(buf[:])
+	dst = append(dst, buf[:]...)
+
+	w := bitWriter{buf: dst}
+	for _, d := range deltas {
+		w.write(d, h.width)
+	}
+	return w.flush()
+}
+
+// decodeBlock decodes the payload of the block h into dst.
+// len(payload) must be h.payloadLen(), and len(dst) must be h.count.
+func decodeBlock(dst []int128.Uint128, h blockHeader, payload []byte) {
+	r := bitReader{buf: payload}
+	v := h.first
+	dst[0] = v
+	for i := 1; i < h.count; i++ {
+		d := unzigzag(r.read(h.width).Add(h.ref))
+		v = v.Add(d)
+		dst[i] = v
+	}
+}
+
+// bitWriter packs the values in little endian bit order.
+type bitWriter struct {
+	buf  []byte
+	acc  uint64
+	bits uint // the number of valid bits in acc
+}
+
+func (w *bitWriter) write(v int128.Uint128, width uint) {
+	if width > 64 {
+		w.write64(v.L, 64)
+		w.write64(v.H, width-64)
+		return
+	}
+	w.write64(v.L, width)
+}
+
+// write64 writes the lower n bits of v. n must be at most 64.
+func (w *bitWriter) write64(v uint64, n uint) {
+	if n == 0 {
+		return
+	}
+	if n < 64 {
+		v &= 1<<n - 1
+	}
+	w.acc |= v << w.bits
+	if w.bits+n < 64 {
+		w.bits += n
+		return
+	}
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], w.acc)
+	w.buf = append(w.buf, b[:]...)
+	// the shift count is 64 if w.bits == 0, and it results in 0.
+	w.acc = v >> (64 - w.bits)
+	w.bits = w.bits + n - 64
+}
+
+// flush writes the remaining bits, and returns the buffer.
+func (w *bitWriter) flush() []byte {
+	for n := (w.bits + 7) / 8; n > 0; n-- {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+	}
+	w.acc, w.bits = 0, 0
+	return w.buf
+}
+
+// bitReader unpacks the values written by bitWriter.
+type bitReader struct {
+	buf  []byte
+	acc  uint64
+	bits uint // the number of valid bits in acc
+}
+
+func (r *bitReader) read(width uint) int128.Uint128 {
+	if width > 64 {
+		l := r.read64(64)
+		h := r.read64(width - 64)
+		return int128.Uint128{H: h, L: l}
+	}
+	return int128.Uint128{H: 0, L: r.read64(width)}
+}
+
+// read64 reads n bits. n must be at most 64.
+func (r *bitReader) read64(n uint) uint64 {
+	if n == 0 {
+		return 0
+	}
+	v := r.acc
+	got := r.bits
+	if got < n {
+		// refill the accumulator.
+		var next uint64
+		var m uint
+		if len(r.buf) >= 8 {
+			next = binary.LittleEndian.Uint64(r.buf)
+			r.buf = r.buf[8:]
+			m = 64
+		} else {
+			for i, b := range r.buf {
+				next |= uint64(b) << (8 * i)
+			}
+			m = uint(8 * len(r.buf))
+			r.buf = nil
+		}
+		// shifts of 64 bits or more result in 0.
+		v |= next << got
+		r.acc = next >> (n - got)
+		r.bits = m - (n - got)
+	} else {
+		r.acc >>= n
+		r.bits -= n
+	}
+	if n < 64 {
+		v &= 1<<n - 1
+	}
+	return v
+}
@@ -0,0 +1,468 @@
+package column
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+)
+
+func encode(t testing.TB, values []int128.Uint128) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	if err := e.WriteUint128s(values); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decode(t testing.TB, data []byte) []int128.Uint128 {
+	t.Helper()
+	d := NewDecoder(bytes.NewReader(data))
+	var ret []int128.Uint128
+	var buf [100]int128.Uint128
+	for {
+		n, err := d.ReadUint128s(buf[:])
+		ret = append(ret, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ret
+}
+
+func sequence(n int, f func(i int) int128.Uint128) []int128.Uint128 {
+	ret := make([]int128.Uint128, n)
+	for i := range ret {
+		ret[i] = f(i)
+	}
+	return ret
+}
+
+func TestRoundTrip(t *testing.T) {
+	max := int128.Uint128{H: math.MaxUint64, L: math.MaxUint64}
+	r := rand.New(rand.NewSource(42))
+	testCases := []struct {
+		name   string
+		values []int128.Uint128
+	}{
+		{"empty", nil},
+		{"one", []int128.Uint128{max}},
+		{"two", []int128.Uint128{{H: 0, L: 1}, max}},
+		{"zeros", make([]int128.Uint128, 1000)},
+		{"counter", sequence(1000, func(i int) int128.Uint128 {
+			return int128.Uint128{H: 1, L: uint64(i)}
+		})},
+		{"decreasing", sequence(1000, func(i int) int128.Uint128 {
+			return int128.Uint128{H: 0, L: uint64(1000 - i)}
+		})},
+		{"wrap around", sequence(BlockSize+1, func(i int) int128.Uint128 {
+			return max.Add(int128.Uint128{H: 0, L: uint64(i)})
+		})},
+		{"extremes", sequence(300, func(i int) int128.Uint128 {
+			if i%2 == 0 {
+				return max
+			}
+			return int128.Uint128{}
+		})},
+		{"random", sequence(1000, func(i int) int128.Uint128 {
+			return int128.Uint128{H: r.Uint64(), L: r.Uint64()}
+		})},
+		{"random walk", func() []int128.Uint128 {
+			var v int128.Uint128
+			return sequence(1000, func(i int) int128.Uint128 {
+				v = v.Add(int128.Int128{H: 0, L: uint64(r.Intn(1 << 20))}.Sub(int128.Int128{H: 0, L: 1 << 19}).Uint128())
+				return v
+			})
+		}()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := encode(t, tc.values)
+			got := decode(t, data)
+			if len(got) != len(tc.values) {
+				t.Fatalf("want %d values, got %d", len(tc.values), len(got))
+			}
+			for i := range got {
+				if got[i] != tc.values[i] {
+					t.Fatalf("%d: want %#v, got %#v", i, tc.values[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundTripInt128(t *testing.T) {
+	values := []int128.Int128{
+		{H: 0, L: 0},
+		{H: -1, L: math.MaxUint64},
+		{H: math.MaxInt64, L: math.MaxUint64},
+		{H: math.MinInt64, L: 0},
+		{H: 0, L: 42},
+		{H: -1, L: 0},
+	}
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	if err := e.WriteInt128s(values); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]int128.Int128, len(values)+1)
+	d := NewDecoder(&buf)
+	n, err := d.ReadInt128s(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(values) {
+		t.Fatalf("want %d values, got %d", len(values), n)
+	}
+	for i := range values {
+		if got[i] != values[i] {
+			t.Errorf("%d: want %#v, got %#v", i, values[i], got[i])
+		}
+	}
+	if n, err := d.ReadInt128s(got); n != 0 || err != io.EOF {
+		t.Errorf("want (0, io.EOF), got (%d, %v)", n, err)
+	}
+}
+
+func TestEncodedSize(t *testing.T) {
+	// the deltas of a counter are constant, so the payloads are empty.
+	values := sequence(BlockSize*4, func(i int) int128.Uint128 {
+		return int128.Uint128{H: 0xdead_beef, L: uint64(i) * 3}
+	})
+	data := encode(t, values)
+	if want := len(magic) + 4*headerSize; len(data) != want {
+		t.Errorf("want %d bytes, got %d", want, len(data))
+	}
+
+	// the deltas are in [0, 16), so they need 4 bits.
+	r := rand.New(rand.NewSource(42))
+	var v int128.Uint128
+	values = sequence(BlockSize, func(i int) int128.Uint128 {
+		v = v.Add(int128.Uint128{H: 0, L: uint64(r.Intn(16))})
+		return v
+	})
+	data = encode(t, values)
+	if max := len(magic) + headerSize + ((BlockSize-1)*5+7)/8; len(data) > max {
+		t.Errorf("want at most %d bytes, got %d", max, len(data))
+	}
+}
+
+func TestFlush(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	var want []int128.Uint128
+	for i := 0; i < 10; i++ {
+		values := sequence(i*7, func(j int) int128.Uint128 {
+			return int128.Uint128{H: uint64(i), L: uint64(j)}
+		})
+		want = append(want, values...)
+		if err := e.WriteUint128s(values); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := decode(t, buf.Bytes())
+	if len(got) != len(want) {
+		t.Fatalf("want %d values, got %d", len(want), len(got))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%d: want %#v, got %#v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestDecoder_Error(t *testing.T) {
+	data := encode(t, sequence(200, func(i int) int128.Uint128 {
+		return int128.Uint128{H: 0, L: uint64(i * i)}
+	}))
+
+	testCases := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, io.EOF},
+		{"only magic", []byte(magic), io.EOF},
+		{"short magic", []byte(magic[:3]), errMagic},
+		{"invalid magic", []byte("hello, world"), errMagic},
+		{"truncated header", data[:len(magic)+10], io.ErrUnexpectedEOF},
+		{"truncated payload", data[:len(magic)+headerSize+10], io.ErrUnexpectedEOF},
+		{"invalid width", func() []byte {
+			b := append([]byte(magic), make([]byte, headerSize)...)
+			b[len(magic)+1] = 129
+			return b
+		}(), errCorrupted},
+		{"invalid count", func() []byte {
+			b := append([]byte(magic), make([]byte, headerSize)...)
+			b[len(magic)] = BlockSize
+			return b
+		}(), errCorrupted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf [BlockSize * 2]int128.Uint128
+			d := NewDecoder(bytes.NewReader(tc.data))
+			var err error
+			for err == nil {
+				_, err = d.ReadUint128s(buf[:])
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %v, got %v", tc.err, err)
+			}
+
+			// the error is sticky.
+			if _, err2 := d.ReadUint128s(buf[:]); err2 != err {
+				t.Errorf("want %v, got %v", err, err2)
+			}
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	var v int128.Uint128
+	values := sequence(1000, func(i int) int128.Uint128 {
+		v = v.Add(int128.Uint128{H: 0, L: uint64(r.Intn(1 << 30))})
+		return v
+	})
+	data := encode(t, values)
+
+	x, err := NewIndex(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != int64(len(values)) {
+		t.Fatalf("want %d values, got %d", len(values), x.Len())
+	}
+
+	for i := 0; i < 1000; i++ {
+		off := r.Intn(len(values))
+		size := r.Intn(300)
+		buf := make([]int128.Uint128, size)
+		n, err := x.ReadUint128sAt(buf, int64(off))
+		want := values[off:]
+		if len(want) > size {
+			want = want[:size]
+		}
+		if n != len(want) {
+			t.Fatalf("ReadUint128sAt(%d, %d): want %d values, got %d", size, off, len(want), n)
+		}
+		if n < size && err != io.EOF {
+			t.Fatalf("ReadUint128sAt(%d, %d): want io.EOF, got %v", size, off, err)
+		}
+		if n == size && err != nil {
+			t.Fatalf("ReadUint128sAt(%d, %d): unexpected error %v", size, off, err)
+		}
+		for j := range want {
+			if buf[j] != want[j] {
+				t.Fatalf("ReadUint128sAt(%d, %d): %d: want %#v, got %#v", size, off, j, want[j], buf[j])
+			}
+		}
+	}
+
+	// Int128
+	buf := make([]int128.Int128, 300)
+	n, err := x.ReadInt128sAt(buf, 800)
+	if n != 200 || err != io.EOF {
+		t.Errorf("want (200, io.EOF), got (%d, %v)", n, err)
+	}
+	for i := 0; i < n; i++ {
+		if buf[i] != values[800+i].Int128() {
+			t.Fatalf("%d: want %#v, got %#v", i, values[800+i], buf[i])
+		}
+	}
+
+	if _, err := x.ReadUint128sAt(make([]int128.Uint128, 1), -1); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestIndex_Error(t *testing.T) {
+	data := encode(t, sequence(200, func(i int) int128.Uint128 {
+		return int128.Uint128{H: 0, L: uint64(i * i)}
+	}))
+	for _, size := range []int{3, len(magic) + 10, len(magic) + headerSize + 10, len(data) - 1} {
+		if _, err := NewIndex(bytes.NewReader(data), int64(size)); err == nil {
+			t.Errorf("%d: want error, got nil", size)
+		}
+	}
+}
+
+func TestBitPackingQuick(t *testing.T) {
+	f := func(values []int128.Uint128, width uint8) bool {
+		w := uint(width % 129)
+		mask := int128.Uint128{H: math.MaxUint64, L: math.MaxUint64}.Rsh(128 - w)
+		if w == 0 {
+			mask = int128.Uint128{}
+		}
+
+		writer := bitWriter{}
+		for _, v := range values {
+			writer.write(v, w)
+		}
+		buf := writer.flush()
+		if len(buf) != (len(values)*int(w)+7)/8 {
+			return false
+		}
+
+		reader := bitReader{buf: buf}
+		for _, v := range values {
+			if reader.read(w) != v.And(mask) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestZigzagQuick(t *testing.T) {
+	f := func(v int128.Uint128) bool {
+		return unzigzag(zigzag(v)) == v
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	testCases := []struct {
+		in, want int128.Uint128
+	}{
+		{int128.Uint128{H: 0, L: 0}, int128.Uint128{H: 0, L: 0}},
+		{int128.Uint128{H: math.MaxUint64, L: math.MaxUint64}, int128.Uint128{H: 0, L: 1}}, // -1
+		{int128.Uint128{H: 0, L: 1}, int128.Uint128{H: 0, L: 2}},
+		{int128.Uint128{H: math.MaxUint64, L: math.MaxUint64 - 1}, int128.Uint128{H: 0, L: 3}}, // -2
+	}
+	for i, tc := range testCases {
+		if got := zigzag(tc.in); got != tc.want {
+			t.Errorf("%d: zigzag(%#v) should %#v, but %#v", i, tc.in, tc.want, got)
+		}
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte{}, false)
+	f.Add([]byte("0123456789abcdef0123456789abcdef"), false)
+	f.Add(bytes.Repeat([]byte{0xff}, 16*200), true)
+	f.Add(bytes.Repeat([]byte{0x01, 0x02, 0x03}, 1000), true)
+	f.Fuzz(func(t *testing.T, data []byte, accumulate bool) {
+		// build the values from data.
+		// if accumulate is true, data are the deltas.
+		var values []int128.Uint128
+		var acc int128.Uint128
+		for len(data) > 0 {
+			var b [16]byte
+			n := copy(b[:], data)
+			data = data[n:]
+			v := int128.Uint128{H: binary.BigEndian.Uint64(b[:8]), L: binary.BigEndian.Uint64(b[8:])}
+			if accumulate {
+				// make the deltas small.
+				acc = acc.Add(v.Int128().Rsh(100).Uint128())
+				v = acc
+			}
+			values = append(values, v)
+		}
+
+		encoded := encode(t, values)
+		got := decode(t, encoded)
+		if len(got) != len(values) {
+			t.Fatalf("want %d values, got %d", len(values), len(got))
+		}
+		for i := range got {
+			if got[i] != values[i] {
+				t.Fatalf("%d: want %#v, got %#v", i, values[i], got[i])
+			}
+		}
+
+		x, err := NewIndex(bytes.NewReader(encoded), int64(len(encoded)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.Len() != int64(len(values)) {
+			t.Fatalf("want %d values, got %d", len(values), x.Len())
+		}
+		if len(values) > 0 {
+			var buf [1]int128.Uint128
+			last := int64(len(values) - 1)
+			if _, err := x.ReadUint128sAt(buf[:], last); err != nil {
+				t.Fatal(err)
+			}
+			if buf[0] != values[last] {
+				t.Fatalf("want %#v, got %#v", values[last], buf[0])
+			}
+		}
+	})
+}
+
+func FuzzDecoder(f *testing.F) {
+	f.Add([]byte(magic))
+	f.Add(append([]byte(magic), make([]byte, headerSize)...))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// it must not panic.
+		var buf [BlockSize]int128.Uint128
+		d := NewDecoder(bytes.NewReader(data))
+		for {
+			if _, err := d.ReadUint128s(buf[:]); err != nil {
+				break
+			}
+		}
+		NewIndex(bytes.NewReader(data), int64(len(data)))
+	})
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	values := sequence(BlockSize*64, func(i int) int128.Uint128 {
+		return int128.Uint128{H: 1, L: uint64(i * i)}
+	})
+	b.SetBytes(int64(len(values) * 16))
+	for i := 0; i < b.N; i++ {
+		e := NewEncoder(io.Discard)
+		e.WriteUint128s(values)
+		e.Close()
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	values := sequence(BlockSize*64, func(i int) int128.Uint128 {
+		return int128.Uint128{H: 1, L: uint64(i * i)}
+	})
+	data := encode(b, values)
+	buf := make([]int128.Uint128, len(values))
+	b.SetBytes(int64(len(values) * 16))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d := NewDecoder(bytes.NewReader(data))
+		d.ReadUint128s(buf)
+	}
+}
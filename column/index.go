@@ -0,0 +1,154 @@
+package column
+
+import (
+	"io"
+	"sort"
+
+	"github.com/shogo82148/int128"
+)
+
+// Index provides random access to an encoded stream.
+// It keeps the headers of all blocks in memory,
+// and decodes only the blocks that contain the requested values.
+// It is safe to call the methods of Index concurrently
+// if the underlying io.ReaderAt is.
+type Index struct {
+	r      io.ReaderAt
+	blocks []blockEntry
+	len    int64
+}
+
+type blockEntry struct {
+	offset int64 // the offset of the payload
+	start  int64 // the index of the first value in the block
+	header blockHeader
+}
+
+// NewIndex reads the block headers of the encoded stream of size bytes from r.
+// It doesn't read the payloads of the blocks.
+func NewIndex(r io.ReaderAt, size int64) (*Index, error) {
+	x := &Index{r: r}
+	if size == 0 {
+		// an empty input is an empty stream.
+		return x, nil
+	}
+
+	var buf [headerSize]byte
+	if size < int64(len(magic)) {
+		return nil, errMagic
+	}
+	if err := readFullAt(r, buf[:len(magic)], 0); err != nil {
+		return nil, err
+	}
+	if string(buf[:len(magic)]) != magic {
+		return nil, errMagic
+	}
+
+	off := int64(len(magic))
+	for off < size {
+		if size-off < headerSize {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err := readFullAt(r, buf[:], off); err != nil {
+			return nil, err
+		}
+		h, err := parseHeader(buf[:])
+		if err != nil {
+			return nil, err
+		}
+		off += headerSize
+		if size-off < int64(h.payloadLen()) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		x.blocks = append(x.blocks, blockEntry{
+			offset: off,
+			start:  x.len,
+			header: h,
+		})
+		off += int64(h.payloadLen())
+		x.len += int64(h.count)
+	}
+	return x, nil
+}
+
+// Len returns the number of values in the stream.
+func (x *Index) Len() int64 {
+	return x.len
+}
+
+// ReadUint128sAt reads len(dst) values starting at the index off.
+// It returns the number of values read and any error encountered.
+// If n < len(dst), it returns a non-nil error; io.EOF if the stream ends.
+func (x *Index) ReadUint128sAt(dst []int128.Uint128, off int64) (int, error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
+
+	var buf [BlockSize]int128.Uint128
+	var payload []byte
+	n := 0
+	i := sort.Search(len(x.blocks), func(i int) bool {
+		b := x.blocks[i]
+		return off < b.start+int64(b.header.count)
+	})
+	for ; n < len(dst) && i < len(x.blocks); i++ {
+		b := x.blocks[i]
+		size := b.header.payloadLen()
+		if cap(payload) < size {
+			payload = make([]byte, size)
+		}
+		payload = payload[:size]
+		if err := readFullAt(x.r, payload, b.offset); err != nil {
+			return n, err
+		}
+		values := buf[:b.header.count]
+		decodeBlock(values, b.header, payload)
+
+		pos := off + int64(n) - b.start
+		n += copy(dst[n:], values[pos:])
+	}
+	if n < len(dst) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// ReadInt128sAt reads len(dst) values starting at the index off.
+// It returns the number of values read and any error encountered.
+// If n < len(dst), it returns a non-nil error; io.EOF if the stream ends.
+func (x *Index) ReadInt128sAt(dst []int128.Int128, off int64) (int, error) {
+	var buf [BlockSize]int128.Uint128
+	n := 0
+	for n < len(dst) {
+		m := len(dst) - n
+		if m > len(buf) {
+			m = len(buf)
+		}
+		m, err := x.ReadUint128sAt(buf[:m], off+int64(n))
+		for i, v := range buf[:m] {
+			dst[n+i] = v.Int128()
+		}
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// readFullAt reads exactly len(buf) bytes from r at the offset off.
+// Some implementations of io.ReaderAt return io.EOF with a full read at the end of the input,
+// and readFullAt treats it as success.
+func readFullAt(r io.ReaderAt, buf []byte, off int64) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	n, err := r.ReadAt(buf, off)
+	if n == len(buf) {
+		return nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+package column
+
+import (
+	"io"
+
+	"github.com/shogo82148/int128"
+)
+
+// Encoder writes the encoded values to an output stream.
+// The values are buffered until a block is full,
+// so Close or Flush must be called after writing the last value.
+type Encoder struct {
+	w           io.Writer
+	values      []int128.Uint128 // the values of the current block
+	deltas      []int128.Uint128 // scratch space for encodeBlock
+	buf         []byte
+	wroteHeader bool
+	err         error
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		w:      w,
+		values: make([]int128.Uint128, 0, BlockSize),
+		deltas: make([]int128.Uint128, 0, BlockSize),
+	}
+}
+
+// WriteUint128s writes the values to the stream.
+func (e *Encoder) WriteUint128s(values []int128.Uint128) error {
+	for _, v := range values {
+		if err := e.write(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteInt128s writes the values to the stream.
+func (e *Encoder) WriteInt128s(values []int128.Int128) error {
+	for _, v := range values {
+		if err := e.write(v.Uint128()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) write(v int128.Uint128) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.values = append(e.values, v)
+	if len(e.values) == BlockSize {
+		return e.Flush()
+	}
+	return nil
+}
+
+// Flush writes the buffered values to the underlying writer as a block.
+// Flushing in the middle of a stream is allowed, but it makes the output larger.
+func (e *Encoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	buf := e.buf[:0]
+	if !e.wroteHeader {
+		buf = append(buf, magic...)
+		e.wroteHeader = true
+	}
+	if len(e.values) > 0 {
+		buf = encodeBlock(buf, e.values, e.deltas)
+		e.values = e.values[:0]
+	}
+	e.buf = buf
+
+	if len(buf) == 0 {
+		return nil
+	}
+	if _, err := e.w.Write(buf); err != nil {
+		e.err = err
+		return err
+	}
+	return nil
+}
+
+// Close flushes the buffered values.
+// It doesn't close the underlying writer.
+func (e *Encoder) Close() error {
+	return e.Flush()
+}
+
+// Decoder reads the encoded values from an input stream.
+type Decoder struct {
+	r          io.Reader
+	values     []int128.Uint128 // the decoded values of the current block
+	pos        int              // the position of the next value in values
+	buf        []byte
+	readHeader bool
+	err        error
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		r:      r,
+		values: make([]int128.Uint128, 0, BlockSize),
+	}
+}
+
+// ReadUint128s reads up to len(dst) values into dst.
+// It returns the number of values read and any error encountered.
+// At the end of the stream, ReadUint128s returns 0, io.EOF.
+func (d *Decoder) ReadUint128s(dst []int128.Uint128) (int, error) {
+	n := 0
+	for n < len(dst) {
+		if d.pos == len(d.values) {
+			if err := d.next(); err != nil {
+				if n > 0 && err == io.EOF {
+					return n, nil
+				}
+				return n, err
+			}
+		}
+		m := copy(dst[n:], d.values[d.pos:])
+		d.pos += m
+		n += m
+	}
+	return n, nil
+}
+
+// ReadInt128s reads up to len(dst) values into dst.
+// It returns the number of values read and any error encountered.
+// At the end of the stream, ReadInt128s returns 0, io.EOF.
+func (d *Decoder) ReadInt128s(dst []int128.Int128) (int, error) {
+	n := 0
+	for n < len(dst) {
+		if d.pos == len(d.values) {
+			if err := d.next(); err != nil {
+				if n > 0 && err == io.EOF {
+					return n, nil
+				}
+				return n, err
+			}
+		}
+		for d.pos < len(d.values) && n < len(dst) {
+			dst[n] = d.values[d.pos].Int128()
+			d.pos++
+			n++
+		}
+	}
+	return n, nil
+}
+
+// next decodes the next block.
+func (d *Decoder) next() error {
+	if d.err != nil {
+		return d.err
+	}
+	if err := d.decode(); err != nil {
+		d.err = err
+		return err
+	}
+	return nil
+}
+
+func (d *Decoder) decode() error {
+	if !d.readHeader {
+		var buf [len(magic)]byte
+		if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				return errMagic
+			}
+			// an empty input is an empty stream.
+			return err
+		}
+		if string(buf[:]) != magic {
+			return errMagic
+		}
+		d.readHeader = true
+	}
+
+	var buf [headerSize]byte
+	if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+		// io.EOF here means the end of the stream.
+		return err
+	}
+	h, err := parseHeader(buf[:])
+	if err != nil {
+		return err
+	}
+
+	n := h.payloadLen()
+	if cap(d.buf) < n {
+		d.buf = make([]byte, n)
+	}
+	payload := d.buf[:n]
+	if _, err := io.ReadFull(d.r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	d.values = d.values[:h.count]
+	d.pos = 0
+	decodeBlock(d.values, h, payload)
+	return nil
+}
@@ -0,0 +1,289 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/shogo82148/int128"
+)
+
+// Decimal128 is an IEEE 754-2008 decimal128 floating-point number in the binary integer decimal (BID) encoding.
+// The underlying Uint128 holds the raw 128 bits of the encoding.
+//
+// A finite Decimal128 represents the value coefficient * 10**exponent,
+// where the coefficient has at most 34 decimal digits.
+type Decimal128 int128.Uint128
+
+const (
+	// the bias of the exponent.
+	bid128Bias = 6176
+
+	// MinExponent128 and MaxExponent128 are the range of the exponent of a finite Decimal128.
+	MinExponent128 = -bid128Bias
+	MaxExponent128 = 3<<12 - 1 - bid128Bias
+
+	// the masks of the most significant 64 bits.
+	bid128SignMask      = 1 << 63
+	bid128InfMask       = 0x1e << 58
+	bid128NaNMask       = 0x1f << 58
+	bid128SignalingMask = 1 << 57
+	bid128LargeMask     = 3 << 61
+)
+
+// the maximum canonical coefficient: 10**34 - 1
+var bid128MaxCoef = int128.Pow10Uint128(34).Sub(int128.Uint128{H: 0, L: 1})
+
+// the maximum canonical payload of NaN: 10**33 - 1
+var bid128MaxPayload = int128.Pow10Uint128(33).Sub(int128.Uint128{H: 0, L: 1})
+
+// NewDecimal128 returns the canonical encoding of the value coef * 10**exp.
+// If the value needs more than 34 digits or the exponent is out of range,
+// NewDecimal128 tries to find an equivalent representation by adjusting the trailing zeros.
+// If there is no exact representation, NewDecimal128 returns an error wrapping ErrRange.
+func NewDecimal128(coef int128.Int128, exp int32) (Decimal128, error) {
+	neg := coef.IsNeg()
+	mag := coef.AbsUint128()
+	e := int64(exp)
+	ten := int128.Uint128{H: 0, L: 10}
+
+	// reduce the number of digits
+	for mag.Cmp(bid128MaxCoef) > 0 {
+		q, r := mag.DivMod(ten)
+		if r.L != 0 {
+			return Decimal128{}, fmt.Errorf("decimal: %s needs more than 34 digits: %w", coef, ErrRange)
+		}
+		mag = q
+		e++
+	}
+
+	if mag.H == 0 && mag.L == 0 {
+		// clamp the exponent of zero
+		if e > MaxExponent128 {
+			e = MaxExponent128
+		} else if e < MinExponent128 {
+			e = MinExponent128
+		}
+	}
+
+	// fold down the exponent
+	for e > MaxExponent128 {
+		m, ok := mag.MulPow10(1)
+		if !ok || m.Cmp(bid128MaxCoef) > 0 {
+			return Decimal128{}, fmt.Errorf("decimal: exponent %d is too large: %w", exp, ErrRange)
+		}
+		mag = m
+		e--
+	}
+
+	// remove the trailing zeros
+	for e < MinExponent128 {
+		q, r := mag.DivMod(ten)
+		if r.L != 0 {
+			return Decimal128{}, fmt.Errorf("decimal: exponent %d is too small: %w", exp, ErrRange)
+		}
+		mag = q
+		e++
+	}
+
+	d := Decimal128{H: mag.H | uint64(e+bid128Bias)<<49, L: mag.L}
+	if neg {
+		d.H |= bid128SignMask
+	}
+	return d, nil
+}
+
+// NewDecimal128Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func NewDecimal128Inf(sign int) Decimal128 {
+	d := Decimal128{H: bid128InfMask}
+	if sign < 0 {
+		d.H |= bid128SignMask
+	}
+	return d
+}
+
+// NewDecimal128NaN returns a quiet NaN, or a signaling NaN if signaling is true.
+// The payload must be less than 10**33; otherwise it is replaced with zero.
+func NewDecimal128NaN(signaling bool, payload int128.Uint128) Decimal128 {
+	if payload.Cmp(bid128MaxPayload) > 0 {
+		payload = int128.Uint128{}
+	}
+	d := Decimal128{H: bid128NaNMask | payload.H, L: payload.L}
+	if signaling {
+		d.H |= bid128SignalingMask
+	}
+	return d
+}
+
+// Signbit reports whether d is negative or negative zero.
+func (d Decimal128) Signbit() bool {
+	return d.H&bid128SignMask != 0
+}
+
+// IsInf reports whether d is an infinity, according to sign.
+// If sign > 0, IsInf reports whether d is positive infinity.
+// If sign < 0, IsInf reports whether d is negative infinity.
+// If sign == 0, IsInf reports whether d is either infinity.
+func (d Decimal128) IsInf(sign int) bool {
+	if d.H&bid128NaNMask != bid128InfMask {
+		return false
+	}
+	return sign == 0 || (sign > 0) != d.Signbit()
+}
+
+// IsNaN reports whether d is a NaN (either quiet or signaling).
+func (d Decimal128) IsNaN() bool {
+	return d.H&bid128NaNMask == bid128NaNMask
+}
+
+// IsSignaling reports whether d is a signaling NaN.
+func (d Decimal128) IsSignaling() bool {
+	return d.IsNaN() && d.H&bid128SignalingMask != 0
+}
+
+// Payload returns the payload of the NaN d.
+// If d is not a NaN or the payload is not canonical, Payload returns zero.
+func (d Decimal128) Payload() int128.Uint128 {
+	if !d.IsNaN() {
+		return int128.Uint128{}
+	}
+	payload := int128.Uint128{H: d.H & (1<<46 - 1), L: d.L}
+	if payload.Cmp(bid128MaxPayload) > 0 {
+		return int128.Uint128{}
+	}
+	return payload
+}
+
+// Decode returns the coefficient and the exponent of the finite number d.
+// If d is a NaN or an infinity, ok is false.
+// Non-canonical coefficients are decoded as zero, as required by IEEE 754-2008.
+// The sign of zero is lost; use Signbit to check it.
+func (d Decimal128) Decode() (coef int128.Int128, exp int32, ok bool) {
+	if d.H&bid128InfMask == bid128InfMask {
+		// NaN or infinity
+		return int128.Int128{}, 0, false
+	}
+
+	var mag int128.Uint128
+	if d.H&bid128LargeMask == bid128LargeMask {
+		// the coefficient is 0b100 << 111 | the trailing 111 bits,
+		// which is always greater than 10**34 - 1 and non-canonical.
+		exp = int32((d.H>>47)&0x3fff) - bid128Bias
+	} else {
+		exp = int32((d.H>>49)&0x3fff) - bid128Bias
+		mag = int128.Uint128{H: d.H & (1<<49 - 1), L: d.L}
+		if mag.Cmp(bid128MaxCoef) > 0 {
+			mag = int128.Uint128{}
+		}
+	}
+
+	coef = mag.Int128()
+	if d.Signbit() {
+		coef = coef.Neg()
+	}
+	return coef, exp, true
+}
+
+// Canonical returns the canonical encoding of d.
+func (d Decimal128) Canonical() Decimal128 {
+	var ret Decimal128
+	switch {
+	case d.IsNaN():
+		ret = NewDecimal128NaN(d.IsSignaling(), d.Payload())
+	case d.IsInf(0):
+		ret = NewDecimal128Inf(0)
+	default:
+		coef, exp, _ := d.Decode()
+		mag := coef.AbsUint128()
+		ret = Decimal128{H: mag.H | uint64(int64(exp)+bid128Bias)<<49, L: mag.L}
+	}
+	ret.H |= d.H & bid128SignMask
+	return ret
+}
+
+// IsCanonical reports whether d is in the canonical encoding.
+func (d Decimal128) IsCanonical() bool {
+	return d == d.Canonical()
+}
+
+// Decimal returns d as a Decimal, and reports whether d is exactly representable as a Decimal.
+func (d Decimal128) Decimal() (Decimal, bool) {
+	coef, exp, ok := d.Decode()
+	if !ok {
+		return Decimal{}, false
+	}
+	if exp > 0 {
+		coef, ok = coef.MulPow10(uint(exp))
+		if !ok {
+			return Decimal{}, false
+		}
+		return Decimal{coef: coef}, true
+	}
+
+	// remove the trailing zeros
+	ten := int128.Int128{H: 0, L: 10}
+	for exp < -MaxScale {
+		q, r := coef.QuoRem(ten)
+		if r.L != 0 {
+			return Decimal{}, false
+		}
+		coef = q
+		exp++
+	}
+	return Decimal{coef: coef, scale: int(-exp)}, true
+}
+
+// Decimal128 returns the canonical encoding of a as a Decimal128.
+// If the coefficient of a has more than 34 significant digits,
+// Decimal128 returns an error wrapping ErrRange.
+func (a Decimal) Decimal128() (Decimal128, error) {
+	return NewDecimal128(a.coef, int32(-a.scale))
+}
+
+// String returns a string representation of d, such as "-12345E-2", "Inf" or "NaN".
+func (d Decimal128) String() string {
+	var sign string
+	if d.Signbit() {
+		sign = "-"
+	}
+	switch {
+	case d.IsSignaling():
+		return sign + "sNaN"
+	case d.IsNaN():
+		return sign + "NaN"
+	case d.IsInf(0):
+		return sign + "Inf"
+	}
+	coef, exp, _ := d.Decode()
+	return sign + coef.AbsUint128().String() + "E" + strconv.Itoa(int(exp))
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+// d is encoded as the 16 bytes of the IEEE 754 decimal128 BID interchange format in little-endian byte order,
+// i.e. the least significant byte of the coefficient comes first and the sign bit is in the last byte.
+// IEEE 754 doesn't define the byte order; little-endian is chosen because
+// the BSON Decimal128 type and the Intel Decimal Floating-Point Math Library on x86 use it.
+// Note that it differs from the big-endian encodings of Uint128 and Int128, such as MarshalDER.
+func (d Decimal128) MarshalBinary() ([]byte, error) {
+	var buf [16]byte
+	for i := 0; i < 8; i++ {
+		buf[i] = byte(d.L >> (8 * i))
+		buf[i+8] = byte(d.H >> (8 * i))
+	}
+	return buf[:], nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+// data must be the 16 bytes in little-endian byte order, as written by MarshalBinary.
+func (d *Decimal128) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return errors.New("decimal: invalid length of decimal128")
+	}
+	var h, l uint64
+	for i := 0; i < 8; i++ {
+		l |= uint64(data[i]) << (8 * i)
+		h |= uint64(data[i+8]) << (8 * i)
+	}
+	*d = Decimal128{H: h, L: l}
+	return nil
+}
@@ -0,0 +1,320 @@
+package decimal
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+)
+
+func TestNewDecimal128(t *testing.T) {
+	testCases := []struct {
+		coef int128.Int128
+		exp  int32
+		want Decimal128
+	}{
+		{int128.Int128{H: 0, L: 0}, 0, Decimal128{H: 0x3040_0000_0000_0000, L: 0}},
+		{int128.Int128{H: 0, L: 1}, 0, Decimal128{H: 0x3040_0000_0000_0000, L: 1}},
+		{int128.Int128{H: -1, L: 1<<64 - 1}, 0, Decimal128{H: 0xb040_0000_0000_0000, L: 1}},
+		{int128.Int128{H: 0, L: 12345}, -2, Decimal128{H: 0x303c_0000_0000_0000, L: 12345}},
+		{int128.Int128{H: 0, L: 1}, MinExponent128, Decimal128{H: 0, L: 1}},
+		{
+			// the max value
+			int128.Pow10Uint128(34).Sub(int128.Uint128{H: 0, L: 1}).Int128(), MaxExponent128,
+			Decimal128{H: 0x5fff_ed09_bead_87c0, L: 0x378d_8e63_ffff_ffff},
+		},
+
+		// canonicalization
+		{int128.Pow10Int128(35), 0, Decimal128{H: 0x3044_314d_c644_8d93, L: 0x38c1_5b0a_0000_0000}},
+		{int128.Int128{H: 0, L: 1}, MaxExponent128 + 1, Decimal128{H: 0x5ffe_0000_0000_0000, L: 10}},
+		{int128.Int128{H: 0, L: 10}, MinExponent128 - 1, Decimal128{H: 0, L: 1}},
+		{int128.Int128{H: 0, L: 0}, MinExponent128 - 100, Decimal128{H: 0, L: 0}},
+		{int128.Int128{H: 0, L: 0}, MaxExponent128 + 100, Decimal128{H: 0x5ffe_0000_0000_0000, L: 0}},
+	}
+
+	for _, tc := range testCases {
+		got, err := NewDecimal128(tc.coef, tc.exp)
+		if err != nil {
+			t.Errorf("%v E %d: unexpected error: %v", tc.coef, tc.exp, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%v E %d: want %#v, got %#v", tc.coef, tc.exp, tc.want, got)
+		}
+	}
+}
+
+func TestNewDecimal128_Error(t *testing.T) {
+	testCases := []struct {
+		coef int128.Int128
+		exp  int32
+	}{
+		{int128.Pow10Int128(34).Add(int128.Int128{H: 0, L: 1}), 0},
+		{int128.Int128{H: 0, L: 1}, MinExponent128 - 1},
+		{int128.Pow10Int128(33), MaxExponent128 + 1},
+	}
+
+	for _, tc := range testCases {
+		_, err := NewDecimal128(tc.coef, tc.exp)
+		if !errors.Is(err, ErrRange) {
+			t.Errorf("%v E %d: want ErrRange, got %v", tc.coef, tc.exp, err)
+		}
+	}
+}
+
+func TestDecimal128_Decode(t *testing.T) {
+	testCases := []struct {
+		d    Decimal128
+		coef int128.Int128
+		exp  int32
+		ok   bool
+	}{
+		{Decimal128{H: 0x3040_0000_0000_0000, L: 1}, int128.Int128{H: 0, L: 1}, 0, true},
+		{Decimal128{H: 0xb03c_0000_0000_0000, L: 12345}, int128.Int128{H: -1, L: -12345 & (1<<64 - 1)}, -2, true},
+		{Decimal128{H: 0x5fff_ed09_bead_87c0, L: 0x378d_8e63_ffff_ffff}, int128.Pow10Int128(34).Sub(int128.Int128{H: 0, L: 1}), MaxExponent128, true},
+
+		// non-canonical coefficients
+		{Decimal128{H: 0x3041_ed09_bead_87c0, L: 0x378d_8e64_0000_0000}, int128.Int128{}, 0, true},
+		{Decimal128{H: 0x6c10_0000_0000_0000, L: 0}, int128.Int128{}, 0, true},
+
+		// NaN and infinities
+		{NewDecimal128Inf(1), int128.Int128{}, 0, false},
+		{NewDecimal128NaN(false, int128.Uint128{}), int128.Int128{}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		coef, exp, ok := tc.d.Decode()
+		if coef != tc.coef || exp != tc.exp || ok != tc.ok {
+			t.Errorf("%#v: want (%v, %d, %t), got (%v, %d, %t)", tc.d, tc.coef, tc.exp, tc.ok, coef, exp, ok)
+		}
+	}
+}
+
+func TestDecimal128_RoundTripQuick(t *testing.T) {
+	f := func(coef int128.Int128, shift uint8, exp int16) bool {
+		coef = coef.Rsh(uint(shift%128) + 15)
+		d, err := NewDecimal128(coef, int32(exp)%6000)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		c, e, ok := d.Decode()
+		return ok && c == coef && e == int32(exp)%6000 && d.IsCanonical()
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecimal128_ValueQuick(t *testing.T) {
+	f := func(coef int128.Int128, shift uint8, exp int16) bool {
+		coef = coef.Rsh(uint(shift % 128))
+		d, err := NewDecimal128(coef, int32(exp))
+		if err != nil {
+			return true
+		}
+		c, e, _ := d.Decode()
+
+		// the value must be preserved
+		want := new(big.Rat).SetInt(int128ToBig(coef))
+		got := new(big.Rat).SetInt(int128ToBig(c))
+		if exp >= 0 {
+			want.Mul(want, new(big.Rat).SetInt(pow10(int(exp))))
+		} else {
+			want.Quo(want, new(big.Rat).SetInt(pow10(-int(exp))))
+		}
+		if e >= 0 {
+			got.Mul(got, new(big.Rat).SetInt(pow10(int(e))))
+		} else {
+			got.Quo(got, new(big.Rat).SetInt(pow10(-int(e))))
+		}
+		return want.Cmp(got) == 0
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecimal128_Special(t *testing.T) {
+	testCases := []struct {
+		d         Decimal128
+		str       string
+		signbit   bool
+		posInf    bool
+		negInf    bool
+		nan       bool
+		signaling bool
+	}{
+		{NewDecimal128Inf(1), "Inf", false, true, false, false, false},
+		{NewDecimal128Inf(-1), "-Inf", true, false, true, false, false},
+		{NewDecimal128NaN(false, int128.Uint128{}), "NaN", false, false, false, true, false},
+		{NewDecimal128NaN(true, int128.Uint128{}), "sNaN", false, false, false, true, true},
+		{Decimal128{H: 0xb040_0000_0000_0000, L: 0}, "-0E0", true, false, false, false, false},
+		{Decimal128{H: 0x303c_0000_0000_0000, L: 12345}, "12345E-2", false, false, false, false, false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.d.String(); got != tc.str {
+			t.Errorf("%#v: want %q, got %q", tc.d, tc.str, got)
+		}
+		if got := tc.d.Signbit(); got != tc.signbit {
+			t.Errorf("%s: Signbit() want %t, got %t", tc.str, tc.signbit, got)
+		}
+		if got := tc.d.IsInf(1); got != tc.posInf {
+			t.Errorf("%s: IsInf(1) want %t, got %t", tc.str, tc.posInf, got)
+		}
+		if got := tc.d.IsInf(-1); got != tc.negInf {
+			t.Errorf("%s: IsInf(-1) want %t, got %t", tc.str, tc.negInf, got)
+		}
+		if got := tc.d.IsInf(0); got != (tc.posInf || tc.negInf) {
+			t.Errorf("%s: IsInf(0) want %t, got %t", tc.str, tc.posInf || tc.negInf, got)
+		}
+		if got := tc.d.IsNaN(); got != tc.nan {
+			t.Errorf("%s: IsNaN() want %t, got %t", tc.str, tc.nan, got)
+		}
+		if got := tc.d.IsSignaling(); got != tc.signaling {
+			t.Errorf("%s: IsSignaling() want %t, got %t", tc.str, tc.signaling, got)
+		}
+	}
+}
+
+func TestDecimal128_Payload(t *testing.T) {
+	payload := int128.Uint128{H: 0, L: 42}
+	d := NewDecimal128NaN(true, payload)
+	if got := d.Payload(); got != payload {
+		t.Errorf("want %v, got %v", payload, got)
+	}
+
+	// non-canonical payload
+	d = NewDecimal128NaN(false, int128.Pow10Uint128(33))
+	if got := d.Payload(); got != (int128.Uint128{}) {
+		t.Errorf("want 0, got %v", got)
+	}
+	d = Decimal128{H: 0x7c00_314d_c644_8d93, L: 0x38c1_5b0a_0000_0000}
+	if got := d.Payload(); got != (int128.Uint128{}) {
+		t.Errorf("want 0, got %v", got)
+	}
+}
+
+func TestDecimal128_Canonical(t *testing.T) {
+	testCases := []struct {
+		d    Decimal128
+		want Decimal128
+	}{
+		// canonical values
+		{Decimal128{H: 0x3040_0000_0000_0000, L: 1}, Decimal128{H: 0x3040_0000_0000_0000, L: 1}},
+		{NewDecimal128Inf(-1), NewDecimal128Inf(-1)},
+
+		// non-canonical coefficient
+		{Decimal128{H: 0xb041_ed09_bead_87c0, L: 0x378d_8e64_0000_0000}, Decimal128{H: 0xb040_0000_0000_0000, L: 0}},
+		{Decimal128{H: 0x6c10_0000_0000_0000, L: 0}, Decimal128{H: 0x3040_0000_0000_0000, L: 0}},
+
+		// infinity with garbage bits
+		{Decimal128{H: 0xf800_0000_0000_00ff, L: 1}, NewDecimal128Inf(-1)},
+
+		// NaN with garbage bits
+		{Decimal128{H: 0x7e10_0000_0000_0000, L: 42}, NewDecimal128NaN(true, int128.Uint128{H: 0, L: 42})},
+		{Decimal128{H: 0x7c00_314d_c644_8d93, L: 0x38c1_5b0a_0000_0000}, NewDecimal128NaN(false, int128.Uint128{})},
+	}
+
+	for _, tc := range testCases {
+		got := tc.d.Canonical()
+		if got != tc.want {
+			t.Errorf("%#v: want %#v, got %#v", tc.d, tc.want, got)
+		}
+		if tc.d.IsCanonical() != (tc.d == tc.want) {
+			t.Errorf("%#v: IsCanonical() want %t", tc.d, tc.d == tc.want)
+		}
+	}
+}
+
+func TestDecimal128_Decimal(t *testing.T) {
+	testCases := []struct {
+		d    Decimal128
+		want string
+		ok   bool
+	}{
+		{Decimal128{H: 0x303c_0000_0000_0000, L: 12345}, "123.45", true},
+		{Decimal128{H: 0x3044_0000_0000_0000, L: 12345}, "1234500", true},
+		{Decimal128{H: 0x0000_0000_0000_0000, L: 10}, "", false},
+		{Decimal128{H: 0x3040_0000_0000_0000 - 40<<49, L: 1000}, "0.00000000000000000000000000000000000010", true},
+		{Decimal128{H: 0x5fff_ed09_bead_87c0, L: 0x378d_8e63_ffff_ffff}, "", false},
+		{NewDecimal128NaN(false, int128.Uint128{}), "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := tc.d.Decimal()
+		if ok != tc.ok || (ok && got.String() != tc.want) {
+			t.Errorf("%s: want (%s, %t), got (%s, %t)", tc.d, tc.want, tc.ok, got, ok)
+		}
+	}
+
+	d, err := mustParse("-123.45").Decimal128()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != (Decimal128{H: 0xb03c_0000_0000_0000, L: 12345}) {
+		t.Errorf("unexpected encoding: %#v", d)
+	}
+}
+
+func TestDecimal128_MarshalBinary(t *testing.T) {
+	// the test vectors from the BSON corpus of the MongoDB specifications,
+	// https://github.com/mongodb/specifications/blob/master/source/bson-corpus/tests/decimal128-1.json
+	mustNew := func(coef int128.Int128, exp int32) Decimal128 {
+		d, err := NewDecimal128(coef, exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	testCases := []struct {
+		name string
+		d    Decimal128
+		want string
+	}{
+		{"Special - Canonical NaN", NewDecimal128NaN(false, int128.Uint128{}), "0000000000000000000000000000007c"},
+		{"Special - Canonical Positive Infinity", NewDecimal128Inf(1), "00000000000000000000000000000078"},
+		{"Special - Canonical Negative Infinity", NewDecimal128Inf(-1), "000000000000000000000000000000f8"},
+		{"Regular - Smallest", mustNew(int128.Int128{H: 0, L: 1234}, -6), "d2040000000000000000000000003430"},
+		{"Regular - 0.1", mustNew(int128.Int128{H: 0, L: 1}, -1), "01000000000000000000000000003e30"},
+		{"Regular - 0", mustNew(int128.Int128{H: 0, L: 0}, 0), "00000000000000000000000000004030"},
+		{"Regular - 1", mustNew(int128.Int128{H: 0, L: 1}, 0), "01000000000000000000000000004030"},
+		{
+			"Regular - Largest",
+			mustNew(int128.Int128{H: 0x3cde6fff9732, L: 0xde825cd07e96aff2}, 0),
+			"f2af967ed05c82de3297ff6fde3c4030",
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := tc.d.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Errorf("%s: want %s, got %x", tc.name, tc.want, got)
+		}
+
+		want, _ := hex.DecodeString(tc.want)
+		var v Decimal128
+		if err := v.UnmarshalBinary(want); err != nil {
+			t.Fatal(err)
+		}
+		if v != tc.d {
+			t.Errorf("%s: want %#v, got %#v", tc.name, tc.d, v)
+		}
+	}
+
+	var v Decimal128
+	if err := v.UnmarshalBinary(make([]byte, 15)); err == nil {
+		t.Error("want error, but not")
+	}
+}
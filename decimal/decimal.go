@@ -0,0 +1,432 @@
+// Package decimal provides a fixed-point decimal type built on [int128.Int128].
+//
+// A [Decimal] represents the value coefficient * 10**-scale exactly.
+// The coefficient has 128 bits, so a Decimal can hold 38 significant digits.
+//
+// The arithmetic operations that may lose precision take an [int128.RoundingMode].
+// The common decimal rounding modes correspond to:
+//
+//	half-even: int128.ToNearestEven
+//	half-up:   int128.ToNearestAway
+//	down:      int128.ToZero
+//	up:        int128.AwayFromZero
+//	ceiling:   int128.ToPositiveInf
+//	floor:     int128.ToNegativeInf
+//
+// The arithmetic operations report whether the result fits in a Decimal,
+// instead of wrapping around silently.
+//
+// [Decimal128] converts the values from/to the IEEE 754-2008 decimal128 format
+// in the binary integer decimal (BID) encoding.
+package decimal
+
+import (
+	"math/bits"
+
+	"github.com/shogo82148/int128"
+	"github.com/shogo82148/int128/internal/arith"
+)
+
+// MaxScale is the maximum scale of a Decimal.
+const MaxScale = 38
+
+// Decimal is a fixed-point decimal number.
+// The zero value of Decimal is 0.
+type Decimal struct {
+	coef  int128.Int128
+	scale int
+}
+
+// New returns a new Decimal with the value coef * 10**-scale.
+// scale must be between 0 and MaxScale, inclusive; otherwise New panics.
+func New(coef int128.Int128, scale int) Decimal {
+	if scale < 0 || scale > MaxScale {
+		panic("decimal: scale out of range")
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// Coefficient returns the coefficient of a.
+func (a Decimal) Coefficient() int128.Int128 {
+	return a.coef
+}
+
+// Scale returns the scale of a, which is the number of digits after the decimal point.
+func (a Decimal) Scale() int {
+	return a.scale
+}
+
+// Sign returns:
+//
+//	-1 if a <  0
+//	 0 if a == 0
+//	+1 if a >  0
+func (a Decimal) Sign() int {
+	return a.coef.Sign()
+}
+
+// Cmp compares a and b and returns:
+//
+//	-1 if a <  b
+//	 0 if a == b
+//	+1 if a >  b
+//
+// a and b may have different scales.
+func (a Decimal) Cmp(b Decimal) int {
+	if a.scale == b.scale {
+		return a.coef.Cmp(b.coef)
+	}
+
+	sa, sb := a.Sign(), b.Sign()
+	if sa != sb {
+		if sa < sb {
+			return -1
+		}
+		return 1
+	}
+
+	// compare the magnitudes in 256 bits.
+	ma, mb := a.coef.AbsUint128(), b.coef.AbsUint128()
+	var ha, la, hb, lb int128.Uint128
+	if a.scale < b.scale {
+		ha, la = mul128(ma, int128.Pow10Uint128(uint(b.scale-a.scale)))
+		lb = mb
+	} else {
+		la = ma
+		hb, lb = mul128(mb, int128.Pow10Uint128(uint(a.scale-b.scale)))
+	}
+	c := ha.Cmp(hb)
+	if c == 0 {
+		c = la.Cmp(lb)
+	}
+	if sa < 0 {
+		c = -c
+	}
+	return c
+}
+
+// Add returns the sum a+b, and reports whether the result fits in Decimal.
+// The scale of the result is the larger of the scales of a and b.
+func (a Decimal) Add(b Decimal) (Decimal, bool) {
+	if a.scale == b.scale {
+		sum := a.coef.Add(b.coef)
+		// overflow occurs iff a and b have the same sign and the sign of sum differs.
+		if (a.coef.H^sum.H)&(b.coef.H^sum.H) < 0 {
+			return Decimal{}, false
+		}
+		return Decimal{coef: sum, scale: a.scale}, true
+	}
+
+	// rescaling may overflow even if the sum fits, so calculate in 256 bits.
+	scale, ha, la, hb, lb := align(a, b)
+	hi, lo := ha.Add(hb), la.Add(lb)
+	if lo.Cmp(la) < 0 {
+		// carry
+		hi = hi.Add(int128.Uint128{H: 0, L: 1})
+	}
+	return fromInt256(hi, lo, scale)
+}
+
+// Sub returns the difference a-b, and reports whether the result fits in Decimal.
+// The scale of the result is the larger of the scales of a and b.
+func (a Decimal) Sub(b Decimal) (Decimal, bool) {
+	if a.scale == b.scale {
+		diff := a.coef.Sub(b.coef)
+		// overflow occurs iff a and b have different signs and the sign of diff differs from a.
+		if (a.coef.H^b.coef.H)&(a.coef.H^diff.H) < 0 {
+			return Decimal{}, false
+		}
+		return Decimal{coef: diff, scale: a.scale}, true
+	}
+
+	// rescaling may overflow even if the difference fits, so calculate in 256 bits.
+	scale, ha, la, hb, lb := align(a, b)
+	hi, lo := ha.Sub(hb), la.Sub(lb)
+	if la.Cmp(lb) < 0 {
+		// borrow
+		hi = hi.Sub(int128.Uint128{H: 0, L: 1})
+	}
+	return fromInt256(hi, lo, scale)
+}
+
+// Mul returns the product a*b rounded to scale according to mode,
+// and reports whether the result fits in Decimal.
+// scale must be between 0 and MaxScale, inclusive; otherwise Mul panics.
+func (a Decimal) Mul(b Decimal, scale int, mode int128.RoundingMode) (Decimal, bool) {
+	if scale < 0 || scale > MaxScale {
+		panic("decimal: scale out of range")
+	}
+	neg := a.coef.IsNeg() != b.coef.IsNeg()
+	hi, lo := mul128(a.coef.AbsUint128(), b.coef.AbsUint128())
+
+	// the scale of the exact product is a.scale + b.scale.
+	exp := a.scale + b.scale - scale
+	if exp <= 0 {
+		if hi.H != 0 || hi.L != 0 {
+			return Decimal{}, false
+		}
+		q, ok := lo.MulPow10(uint(-exp))
+		if !ok {
+			return Decimal{}, false
+		}
+		return fromSignMagnitude(neg, q, scale)
+	}
+
+	hi, lo, rem := quoPow10(hi, lo, exp)
+	if hi.H != 0 || hi.L != 0 {
+		return Decimal{}, false
+	}
+	return round(neg, lo, rem, scale, mode)
+}
+
+// Div returns the quotient a/b rounded to scale according to mode,
+// and reports whether the result fits in Decimal.
+// If b == 0, a division-by-zero run-time panic occurs.
+// scale must be between 0 and MaxScale, inclusive; otherwise Div panics.
+func (a Decimal) Div(b Decimal, scale int, mode int128.RoundingMode) (Decimal, bool) {
+	if scale < 0 || scale > MaxScale {
+		panic("decimal: scale out of range")
+	}
+	if b.Sign() == 0 {
+		panic("decimal: division by zero")
+	}
+	neg := a.coef.IsNeg() != b.coef.IsNeg()
+	ma, mb := a.coef.AbsUint128(), b.coef.AbsUint128()
+
+	// the coefficient of the quotient is a.coef * 10**exp / b.coef.
+	exp := scale + b.scale - a.scale
+	if exp < 0 {
+		hi, lo := mul128(mb, int128.Pow10Uint128(uint(-exp)))
+		if hi.H != 0 || hi.L != 0 {
+			// the divisor is greater than 2 * |a.coef|,
+			// so the quotient is zero and the remainder is less than the half of the divisor.
+			rem := remZero
+			if a.coef.H != 0 || a.coef.L != 0 {
+				rem = remLow
+			}
+			return round(neg, int128.Uint128{}, rem, scale, mode)
+		}
+		q, r := ma.DivMod(lo)
+		return round(neg, q, classify(r, lo), scale, mode)
+	}
+
+	var hi, lo int128.Uint128
+	if exp <= MaxScale {
+		hi, lo = mul128(ma, int128.Pow10Uint128(uint(exp)))
+	} else {
+		var ok bool
+		hi, lo = mul128(ma, int128.Pow10Uint128(MaxScale))
+		hi, lo, ok = mulPow10(hi, lo, exp-MaxScale)
+		if !ok {
+			return Decimal{}, false
+		}
+	}
+	if hi.Cmp(mb) >= 0 {
+		return Decimal{}, false
+	}
+	q, r := div128(hi, lo, mb)
+	return round(neg, q, classify(r, mb), scale, mode)
+}
+
+// Rescale returns a rounded to scale according to mode,
+// and reports whether the result fits in Decimal.
+// scale must be between 0 and MaxScale, inclusive; otherwise Rescale panics.
+func (a Decimal) Rescale(scale int, mode int128.RoundingMode) (Decimal, bool) {
+	if scale < 0 || scale > MaxScale {
+		panic("decimal: scale out of range")
+	}
+	if scale >= a.scale {
+		coef, ok := a.coef.MulPow10(uint(scale - a.scale))
+		if !ok {
+			return Decimal{}, false
+		}
+		return Decimal{coef: coef, scale: scale}, true
+	}
+	return Decimal{coef: a.coef.DivPow10(uint(a.scale-scale), mode), scale: scale}, true
+}
+
+// align rescales a and b to the larger of their scales,
+// and returns the coefficients as 256-bit two's complement integers.
+func align(a, b Decimal) (scale int, ha, la, hb, lb int128.Uint128) {
+	scale = a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	ha, la = toInt256(a.coef, scale-a.scale)
+	hb, lb = toInt256(b.coef, scale-b.scale)
+	return
+}
+
+// toInt256 returns coef * 10**n as a 256-bit two's complement integer.
+func toInt256(coef int128.Int128, n int) (hi, lo int128.Uint128) {
+	hi, lo = mul128(coef.AbsUint128(), int128.Pow10Uint128(uint(n)))
+	if coef.IsNeg() {
+		// negate the 256-bit integer
+		lo = lo.Neg()
+		hi = hi.Not()
+		if lo.H == 0 && lo.L == 0 {
+			hi = hi.Add(int128.Uint128{H: 0, L: 1})
+		}
+	}
+	return
+}
+
+// fromInt256 returns the Decimal value of the 256-bit two's complement integer (hi, lo) and the scale,
+// and reports whether the value fits in Decimal.
+func fromInt256(hi, lo int128.Uint128, scale int) (Decimal, bool) {
+	// the value fits iff hi is the sign extension of lo.
+	ext := uint64(lo.Int128().H >> 63)
+	if hi.H != ext || hi.L != ext {
+		return Decimal{}, false
+	}
+	return Decimal{coef: lo.Int128(), scale: scale}, true
+}
+
+// fromSignMagnitude returns the Decimal value of the sign neg, the magnitude mag and the scale,
+// and reports whether the value fits in Decimal.
+func fromSignMagnitude(neg bool, mag int128.Uint128, scale int) (Decimal, bool) {
+	coef, ok := int128.Int128FromSignMagnitude(neg, mag)
+	if !ok {
+		return Decimal{}, false
+	}
+	return Decimal{coef: coef, scale: scale}, true
+}
+
+// remainder classifies the discarded fraction of a quotient.
+type remainder int
+
+const (
+	remZero remainder = iota // the fraction is zero
+	remLow                   // 0 < fraction < 1/2
+	remHalf                  // fraction == 1/2
+	remHigh                  // 1/2 < fraction < 1
+)
+
+// classify classifies the remainder r of the division by d.
+func classify(r, d int128.Uint128) remainder {
+	if r.H == 0 && r.L == 0 {
+		return remZero
+	}
+	switch r.Cmp(d.Sub(r)) {
+	case -1:
+		return remLow
+	case 0:
+		return remHalf
+	}
+	return remHigh
+}
+
+// round rounds the magnitude q of the quotient according to mode,
+// and returns the result with the sign neg and the scale.
+func round(neg bool, q int128.Uint128, rem remainder, scale int, mode int128.RoundingMode) (Decimal, bool) {
+	var up bool
+	if rem != remZero {
+		switch mode {
+		case int128.ToNearestEven:
+			up = rem == remHigh || (rem == remHalf && q.L&1 != 0)
+		case int128.ToNearestAway:
+			up = rem != remLow
+		case int128.ToZero:
+			up = false
+		case int128.AwayFromZero:
+			up = true
+		case int128.ToNegativeInf:
+			up = neg
+		case int128.ToPositiveInf:
+			up = !neg
+		default:
+			panic("decimal: invalid rounding mode")
+		}
+	}
+	if up {
+		if q.H == 1<<64-1 && q.L == 1<<64-1 {
+			return Decimal{}, false
+		}
+		q = q.Add(int128.Uint128{H: 0, L: 1})
+	}
+	return fromSignMagnitude(neg, q, scale)
+}
+
+// quoPow10 returns the quotient of the 256-bit integer (hi, lo) divided by 10**n for n > 0,
+// and the classification of the remainder.
+func quoPow10(hi, lo int128.Uint128, n int) (int128.Uint128, int128.Uint128, remainder) {
+	var sticky bool
+	for n > 19 {
+		var r uint64
+		hi, lo, r = quoUint64(hi, lo, 1e19)
+		sticky = sticky || r != 0
+		n -= 19
+	}
+
+	d := int128.Pow10Uint128(uint(n))
+	hi, lo, r := quoUint64(hi, lo, d.L)
+	rem := classify(int128.Uint128{H: 0, L: r}, d)
+	if sticky {
+		// 10**n is even, so the sticky bits never make the fraction reach 1/2 from below.
+		switch rem {
+		case remZero:
+			rem = remLow
+		case remHalf:
+			rem = remHigh
+		}
+	}
+	return hi, lo, rem
+}
+
+// quoUint64 returns the quotient and the remainder of the 256-bit integer (hi, lo) divided by d.
+func quoUint64(hi, lo int128.Uint128, d uint64) (int128.Uint128, int128.Uint128, uint64) {
+	var r uint64
+	hi.H, r = bits.Div64(0, hi.H, d)
+	hi.L, r = bits.Div64(r, hi.L, d)
+	lo.H, r = bits.Div64(r, lo.H, d)
+	lo.L, r = bits.Div64(r, lo.L, d)
+	return hi, lo, r
+}
+
+// mulPow10 returns the 256-bit product (hi, lo) * 10**n,
+// and reports whether the product fits in 256 bits.
+func mulPow10(hi, lo int128.Uint128, n int) (int128.Uint128, int128.Uint128, bool) {
+	for n > 0 {
+		m := 19
+		if n < m {
+			m = n
+		}
+		var ok bool
+		hi, lo, ok = mulUint64(hi, lo, int128.Pow10Uint128(uint(m)).L)
+		if !ok {
+			return hi, lo, false
+		}
+		n -= m
+	}
+	return hi, lo, true
+}
+
+// mulUint64 returns the 256-bit product (hi, lo) * m,
+// and reports whether the product fits in 256 bits.
+func mulUint64(hi, lo int128.Uint128, m uint64) (int128.Uint128, int128.Uint128, bool) {
+	var c, t uint64
+	c, lo.L = bits.Mul64(lo.L, m)
+	t, lo.H = bits.Mul64(lo.H, m)
+	lo.H, c = bits.Add64(lo.H, c, 0)
+	c += t
+	t, hi.L = bits.Mul64(hi.L, m)
+	hi.L, c = bits.Add64(hi.L, c, 0)
+	c += t
+	t, hi.H = bits.Mul64(hi.H, m)
+	hi.H, c = bits.Add64(hi.H, c, 0)
+	c += t
+	return hi, lo, c == 0
+}
+
+// mul128 returns the 256-bit product of x and y: (hi, lo) = x * y.
+func mul128(x, y int128.Uint128) (hi, lo int128.Uint128) {
+	h, l := arith.Mul128(arith.Uint128(x), arith.Uint128(y))
+	return int128.Uint128(h), int128.Uint128(l)
+}
+
+// div128 returns the quotient and remainder of (hi, lo) divided by y.
+// div128 panics for y == 0 (division by zero) or y <= hi (quotient overflow).
+func div128(hi, lo, y int128.Uint128) (quo, rem int128.Uint128) {
+	q, r := arith.Div128(arith.Uint128(hi), arith.Uint128(lo), arith.Uint128(y))
+	return int128.Uint128(q), int128.Uint128(r)
+}
@@ -0,0 +1,417 @@
+package decimal
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+)
+
+var roundingModes = []int128.RoundingMode{
+	int128.ToNearestEven,
+	int128.ToNearestAway,
+	int128.ToZero,
+	int128.AwayFromZero,
+	int128.ToNegativeInf,
+	int128.ToPositiveInf,
+}
+
+var bigMinInt128 = new(big.Int).Lsh(big.NewInt(-1), 127)
+var bigMaxInt128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+
+func int128ToBig(x int128.Int128) *big.Int {
+	b := new(big.Int).SetInt64(x.H)
+	b.Lsh(b, 64)
+	return b.Add(b, new(big.Int).SetUint64(x.L))
+}
+
+func bigToInt128(x *big.Int) (int128.Int128, bool) {
+	if x.Cmp(bigMinInt128) < 0 || x.Cmp(bigMaxInt128) > 0 {
+		return int128.Int128{}, false
+	}
+	l := new(big.Int).And(x, new(big.Int).SetUint64(1<<64-1))
+	h := new(big.Int).Rsh(x, 64)
+	return int128.Int128{H: h.Int64(), L: l.Uint64()}, true
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// bigQuoRound returns the quotient a/b rounded according to mode.
+func bigQuoRound(a, b *big.Int, mode int128.RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	neg := a.Sign()*b.Sign() < 0
+	var up bool
+	switch mode {
+	case int128.ToNearestEven, int128.ToNearestAway:
+		r2 := new(big.Int).Abs(r)
+		r2.Lsh(r2, 1)
+		switch r2.CmpAbs(b) {
+		case 1:
+			up = true
+		case 0:
+			up = mode == int128.ToNearestAway || q.Bit(0) != 0
+		}
+	case int128.ToZero:
+	case int128.AwayFromZero:
+		up = true
+	case int128.ToNegativeInf:
+		up = neg
+	case int128.ToPositiveInf:
+		up = !neg
+	}
+	if up {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// randDecimal makes a Decimal from random inputs of testing/quick.
+func randDecimal(coef int128.Int128, shift, scale uint8) Decimal {
+	return New(coef.Rsh(uint(shift%128)), int(scale%(MaxScale+1)))
+}
+
+func mustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestNew(t *testing.T) {
+	d := New(int128.Int128{H: 0, L: 12345}, 2)
+	if d.Coefficient() != (int128.Int128{H: 0, L: 12345}) {
+		t.Errorf("unexpected coefficient: %v", d.Coefficient())
+	}
+	if d.Scale() != 2 {
+		t.Errorf("unexpected scale: %d", d.Scale())
+	}
+
+	for _, scale := range []int{-1, MaxScale + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("New(_, %d): want panic, but not", scale)
+				}
+			}()
+			New(int128.Int128{}, scale)
+		}()
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"0", "0", 0},
+		{"0", "0.000", 0},
+		{"1", "1.000", 0},
+		{"1.001", "1", 1},
+		{"1", "1.001", -1},
+		{"-1.001", "-1", -1},
+		{"-1", "-1.001", 1},
+		{"-1", "1", -1},
+		{"0", "-0.0001", 1},
+		{"170141183460469231731687303715884105727", "0.00000000000000000000000000000000000001", 1},
+		{"-170141183460469231731687303715884105728", "-0.00000000000000000000000000000000000001", -1},
+	}
+
+	for _, tc := range testCases {
+		got := mustParse(tc.a).Cmp(mustParse(tc.b))
+		if got != tc.want {
+			t.Errorf("%s cmp %s should %d, but %d", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
+
+func TestDecimal_CmpQuick(t *testing.T) {
+	f := func(ca, cb int128.Int128, la, lb, sa, sb uint8) int {
+		a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+		return a.Cmp(b)
+	}
+	g := func(ca, cb int128.Int128, la, lb, sa, sb uint8) int {
+		a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+		ra := new(big.Rat).SetFrac(int128ToBig(a.coef), pow10(a.scale))
+		rb := new(big.Rat).SetFrac(int128ToBig(b.coef), pow10(b.scale))
+		return ra.Cmp(rb)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecimal_Add(t *testing.T) {
+	testCases := []struct {
+		a, b, want string
+		ok         bool
+	}{
+		{"1.5", "2.25", "3.75", true},
+		{"-1.5", "2.25", "0.75", true},
+		{"1", "0.001", "1.001", true},
+		{"170141183460469231731687303715884105727", "1", "", false},
+		{"-170141183460469231731687303715884105728", "-1", "", false},
+		{"1701411834604692317316873037158841058", "0.01", "", false},
+		{"1701411834604692317316873037158841058", "-0.73", "1701411834604692317316873037158841057.27", true},
+	}
+
+	for _, tc := range testCases {
+		got, ok := mustParse(tc.a).Add(mustParse(tc.b))
+		if ok != tc.ok || (ok && got.String() != tc.want) {
+			t.Errorf("%s + %s should (%s, %t), but (%s, %t)", tc.a, tc.b, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestDecimal_AddQuick(t *testing.T) {
+	f := func(ca, cb int128.Int128, la, lb, sa, sb uint8) (Decimal, bool) {
+		a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+		return a.Add(b)
+	}
+	g := func(ca, cb int128.Int128, la, lb, sa, sb uint8) (Decimal, bool) {
+		a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+		scale := a.scale
+		if b.scale > scale {
+			scale = b.scale
+		}
+		x := new(big.Int).Mul(int128ToBig(a.coef), pow10(scale-a.scale))
+		y := new(big.Int).Mul(int128ToBig(b.coef), pow10(scale-b.scale))
+		coef, ok := bigToInt128(x.Add(x, y))
+		if !ok {
+			return Decimal{}, false
+		}
+		return New(coef, scale), true
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecimal_SubQuick(t *testing.T) {
+	f := func(ca, cb int128.Int128, la, lb, sa, sb uint8) (Decimal, bool) {
+		a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+		return a.Sub(b)
+	}
+	g := func(ca, cb int128.Int128, la, lb, sa, sb uint8) (Decimal, bool) {
+		a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+		scale := a.scale
+		if b.scale > scale {
+			scale = b.scale
+		}
+		x := new(big.Int).Mul(int128ToBig(a.coef), pow10(scale-a.scale))
+		y := new(big.Int).Mul(int128ToBig(b.coef), pow10(scale-b.scale))
+		coef, ok := bigToInt128(x.Sub(x, y))
+		if !ok {
+			return Decimal{}, false
+		}
+		return New(coef, scale), true
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecimal_Mul(t *testing.T) {
+	testCases := []struct {
+		a, b  string
+		scale int
+		mode  int128.RoundingMode
+		want  string
+		ok    bool
+	}{
+		{"1.5", "2.5", 2, int128.ToNearestEven, "3.75", true},
+		{"1.5", "2.5", 1, int128.ToNearestEven, "3.8", true},
+		{"1.5", "2.5", 1, int128.ToZero, "3.7", true},
+		{"-1.5", "2.5", 1, int128.ToNearestAway, "-3.8", true},
+		{"-1.5", "2.5", 1, int128.ToNegativeInf, "-3.8", true},
+		{"-1.5", "2.5", 1, int128.ToPositiveInf, "-3.7", true},
+		{"1.5", "2", 3, int128.ToNearestEven, "3.000", true},
+		{
+			"1.000000000000000000000000000000000001", "1.000000000000000000000000000000000001", 36, int128.ToNearestEven,
+			"1.000000000000000000000000000000000002", true,
+		},
+		{"170141183460469231731687303715884105727", "2", 0, int128.ToNearestEven, "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := mustParse(tc.a).Mul(mustParse(tc.b), tc.scale, tc.mode)
+		if ok != tc.ok || (ok && got.String() != tc.want) {
+			t.Errorf("%s * %s (%d, %s) should (%s, %t), but (%s, %t)", tc.a, tc.b, tc.scale, tc.mode, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestDecimal_MulQuick(t *testing.T) {
+	for _, mode := range roundingModes {
+		mode := mode
+		f := func(ca, cb int128.Int128, la, lb, sa, sb, s uint8) (Decimal, bool) {
+			a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+			return a.Mul(b, int(s%(MaxScale+1)), mode)
+		}
+		g := func(ca, cb int128.Int128, la, lb, sa, sb, s uint8) (Decimal, bool) {
+			a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+			scale := int(s % (MaxScale + 1))
+			x := new(big.Int).Mul(int128ToBig(a.coef), int128ToBig(b.coef))
+			exp := a.scale + b.scale - scale
+			if exp <= 0 {
+				x.Mul(x, pow10(-exp))
+			} else {
+				x = bigQuoRound(x, pow10(exp), mode)
+			}
+			coef, ok := bigToInt128(x)
+			if !ok {
+				return Decimal{}, false
+			}
+			return New(coef, scale), true
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+	}
+}
+
+func TestDecimal_Div(t *testing.T) {
+	testCases := []struct {
+		a, b  string
+		scale int
+		mode  int128.RoundingMode
+		want  string
+		ok    bool
+	}{
+		{"1", "3", 5, int128.ToNearestEven, "0.33333", true},
+		{"2", "3", 5, int128.ToNearestEven, "0.66667", true},
+		{"2", "3", 5, int128.ToZero, "0.66666", true},
+		{"-2", "3", 5, int128.ToZero, "-0.66666", true},
+		{"-2", "3", 5, int128.ToNegativeInf, "-0.66667", true},
+		{"1", "8", 2, int128.ToNearestEven, "0.12", true},
+		{"1", "8", 2, int128.ToNearestAway, "0.13", true},
+		{"1.00", "0.001", 0, int128.ToNearestEven, "1000", true},
+		{"1", "0.00000000000000000000000000000000000001", 0, int128.ToNearestEven, "100000000000000000000000000000000000000", true},
+		{"1", "0.00000000000000000000000000000000000001", 1, int128.ToNearestEven, "", false},
+		{"0.00000000000000000000000000000000000001", "170141183460469231731687303715884105727", 0, int128.ToPositiveInf, "1", true},
+		{"0.00000000000000000000000000000000000001", "170141183460469231731687303715884105727", 0, int128.ToNearestEven, "0", true},
+	}
+
+	for _, tc := range testCases {
+		got, ok := mustParse(tc.a).Div(mustParse(tc.b), tc.scale, tc.mode)
+		if ok != tc.ok || (ok && got.String() != tc.want) {
+			t.Errorf("%s / %s (%d, %s) should (%s, %t), but (%s, %t)", tc.a, tc.b, tc.scale, tc.mode, tc.want, tc.ok, got, ok)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("want panic, but not")
+			}
+		}()
+		mustParse("1").Div(mustParse("0.00"), 0, int128.ToNearestEven)
+	}()
+}
+
+func TestDecimal_DivQuick(t *testing.T) {
+	for _, mode := range roundingModes {
+		mode := mode
+		f := func(ca, cb int128.Int128, la, lb, sa, sb, s uint8) (Decimal, bool) {
+			a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+			if b.Sign() == 0 {
+				return Decimal{}, false
+			}
+			return a.Div(b, int(s%(MaxScale+1)), mode)
+		}
+		g := func(ca, cb int128.Int128, la, lb, sa, sb, s uint8) (Decimal, bool) {
+			a, b := randDecimal(ca, la, sa), randDecimal(cb, lb, sb)
+			if b.Sign() == 0 {
+				return Decimal{}, false
+			}
+			scale := int(s % (MaxScale + 1))
+			x := int128ToBig(a.coef)
+			y := int128ToBig(b.coef)
+			exp := scale + b.scale - a.scale
+			if exp >= 0 {
+				x.Mul(x, pow10(exp))
+			} else {
+				y.Mul(y, pow10(-exp))
+			}
+			coef, ok := bigToInt128(bigQuoRound(x, y, mode))
+			if !ok {
+				return Decimal{}, false
+			}
+			return New(coef, scale), true
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+	}
+}
+
+func TestDecimal_Rescale(t *testing.T) {
+	testCases := []struct {
+		a     string
+		scale int
+		mode  int128.RoundingMode
+		want  string
+		ok    bool
+	}{
+		{"1.25", 1, int128.ToNearestEven, "1.2", true},
+		{"1.35", 1, int128.ToNearestEven, "1.4", true},
+		{"1.25", 1, int128.ToNearestAway, "1.3", true},
+		{"-1.25", 1, int128.ToNearestAway, "-1.3", true},
+		{"1.29", 1, int128.ToZero, "1.2", true},
+		{"-1.21", 1, int128.ToNegativeInf, "-1.3", true},
+		{"1.21", 1, int128.ToPositiveInf, "1.3", true},
+		{"1.2", 4, int128.ToNearestEven, "1.2000", true},
+		{"100", 37, int128.ToNearestEven, "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := mustParse(tc.a).Rescale(tc.scale, tc.mode)
+		if ok != tc.ok || (ok && got.String() != tc.want) {
+			t.Errorf("%s.Rescale(%d, %s) should (%s, %t), but (%s, %t)", tc.a, tc.scale, tc.mode, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func BenchmarkDecimal_Add(b *testing.B) {
+	x := mustParse("12345.678901234567890123")
+	y := mustParse("98765.4321")
+	for i := 0; i < b.N; i++ {
+		x.Add(y)
+	}
+}
+
+func BenchmarkDecimal_Mul(b *testing.B) {
+	x := mustParse("12345.678901234567890123")
+	y := mustParse("98765.4321")
+	for i := 0; i < b.N; i++ {
+		x.Mul(y, 18, int128.ToNearestEven)
+	}
+}
+
+func BenchmarkDecimal_Div(b *testing.B) {
+	x := mustParse("12345.678901234567890123")
+	y := mustParse("98765.4321")
+	for i := 0; i < b.N; i++ {
+		x.Div(y, 18, int128.ToNearestEven)
+	}
+}
@@ -0,0 +1,24 @@
+package decimal_test
+
+import (
+	"fmt"
+
+	"github.com/shogo82148/int128"
+	"github.com/shogo82148/int128/decimal"
+)
+
+func ExampleDecimal_Mul() {
+	price, _ := decimal.Parse("19.99")
+	rate, _ := decimal.Parse("0.0825")
+	tax, _ := price.Mul(rate, 2, int128.ToNearestEven)
+	fmt.Println(tax)
+	// Output: 1.65
+}
+
+func ExampleDecimal_Div() {
+	a, _ := decimal.Parse("1")
+	b, _ := decimal.Parse("3")
+	c, _ := a.Div(b, 18, int128.ToNearestEven)
+	fmt.Println(c)
+	// Output: 0.333333333333333333
+}
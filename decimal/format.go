@@ -0,0 +1,146 @@
+package decimal
+
+import (
+	"fmt"
+
+	"github.com/shogo82148/int128"
+)
+
+// ErrSyntax indicates that a value does not have the right syntax for a Decimal.
+// It is the same value as int128.ErrSyntax.
+var ErrSyntax = int128.ErrSyntax
+
+// ErrRange indicates that a value is out of range for a Decimal.
+// It is the same value as int128.ErrRange.
+var ErrRange = int128.ErrRange
+
+// String returns the decimal representation of a, such as "-123.4500".
+// The number of digits after the decimal point is equal to the scale of a.
+func (a Decimal) String() string {
+	var buf [48]byte
+	return string(a.Append(buf[:0]))
+}
+
+// Append appends the decimal representation of a, as generated by a.String(), to dst and returns the extended buffer.
+func (a Decimal) Append(dst []byte) []byte {
+	if a.coef.IsNeg() {
+		dst = append(dst, '-')
+	}
+
+	var buf [48]byte
+	digits := a.coef.AbsUint128().Append(buf[:0], 10)
+	if len(digits) <= a.scale {
+		// pad with zeros, e.g. "0.0012"
+		dst = append(dst, '0', '.')
+		for i := len(digits); i < a.scale; i++ {
+			dst = append(dst, '0')
+		}
+		return append(dst, digits...)
+	}
+
+	i := len(digits) - a.scale
+	dst = append(dst, digits[:i]...)
+	if a.scale > 0 {
+		dst = append(dst, '.')
+		dst = append(dst, digits[i:]...)
+	}
+	return dst
+}
+
+// Parse parses s as a decimal number, such as "-123.4500" or "1.5e-3".
+// The scale of the result is the number of digits after the decimal point minus the exponent,
+// or zero if it is negative.
+func Parse(s string) (Decimal, error) {
+	d, err := parse(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("decimal: parsing %q: %w", s, err)
+	}
+	return d, nil
+}
+
+func parse(s string) (Decimal, error) {
+	i := 0
+	neg := false
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		neg = s[i] == '-'
+		i++
+	}
+
+	var mag int128.Uint128
+	var ok = true
+	var numDigits, scale int
+	var dot bool
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '.' {
+			if dot {
+				return Decimal{}, ErrSyntax
+			}
+			dot = true
+			continue
+		}
+		if c < '0' || c > '9' {
+			break
+		}
+		numDigits++
+		if dot {
+			scale++
+		}
+		var o bool
+		mag, o = mag.MulPow10(1)
+		ok = ok && o
+		sum := mag.Add(int128.Uint128{H: 0, L: uint64(c - '0')})
+		ok = ok && sum.Cmp(mag) >= 0
+		mag = sum
+	}
+	if numDigits == 0 {
+		return Decimal{}, ErrSyntax
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		expNeg := false
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			expNeg = s[i] == '-'
+			i++
+		}
+		if i == len(s) {
+			return Decimal{}, ErrSyntax
+		}
+		exp := 0
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c < '0' || c > '9' {
+				return Decimal{}, ErrSyntax
+			}
+			if exp < 1e6 {
+				exp = exp*10 + int(c-'0')
+			}
+		}
+		if expNeg {
+			scale += exp
+		} else {
+			scale -= exp
+		}
+	}
+	if i != len(s) {
+		return Decimal{}, ErrSyntax
+	}
+
+	if !ok || scale > MaxScale {
+		return Decimal{}, ErrRange
+	}
+	if scale < 0 {
+		var o bool
+		mag, o = mag.MulPow10(uint(-scale))
+		if !o {
+			return Decimal{}, ErrRange
+		}
+		scale = 0
+	}
+	d, ok := fromSignMagnitude(neg, mag, scale)
+	if !ok {
+		return Decimal{}, ErrRange
+	}
+	return d, nil
+}
@@ -0,0 +1,140 @@
+package decimal
+
+import (
+	"errors"
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+)
+
+func TestDecimal_String(t *testing.T) {
+	testCases := []struct {
+		a    Decimal
+		want string
+	}{
+		{Decimal{}, "0"},
+		{New(int128.Int128{H: 0, L: 0}, 3), "0.000"},
+		{New(int128.Int128{H: 0, L: 12345}, 0), "12345"},
+		{New(int128.Int128{H: 0, L: 12345}, 2), "123.45"},
+		{New(int128.Int128{H: 0, L: 12345}, 5), "0.12345"},
+		{New(int128.Int128{H: 0, L: 12345}, 7), "0.0012345"},
+		{New(int128.Int128{H: -1, L: -12345 & (1<<64 - 1)}, 7), "-0.0012345"},
+		{New(int128.Int128{H: -1 << 63, L: 0}, 38), "-1.70141183460469231731687303715884105728"},
+		{New(int128.Int128{H: 1<<63 - 1, L: 1<<64 - 1}, 0), "170141183460469231731687303715884105727"},
+	}
+
+	for _, tc := range testCases {
+		got := tc.a.String()
+		if got != tc.want {
+			t.Errorf("string of %#v should %q, but %q", tc.a, tc.want, got)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want Decimal
+	}{
+		{"0", Decimal{}},
+		{"+0", Decimal{}},
+		{"-0", Decimal{}},
+		{"0.000", New(int128.Int128{H: 0, L: 0}, 3)},
+		{"123.45", New(int128.Int128{H: 0, L: 12345}, 2)},
+		{"-123.45", New(int128.Int128{H: -1, L: -12345 & (1<<64 - 1)}, 2)},
+		{".5", New(int128.Int128{H: 0, L: 5}, 1)},
+		{"5.", New(int128.Int128{H: 0, L: 5}, 0)},
+		{"1.5e3", New(int128.Int128{H: 0, L: 1500}, 0)},
+		{"1.5E-3", New(int128.Int128{H: 0, L: 15}, 4)},
+		{"15e+1", New(int128.Int128{H: 0, L: 150}, 0)},
+		{"170141183460469231731687303715884105727", New(int128.Int128{H: 1<<63 - 1, L: 1<<64 - 1}, 0)},
+		{"-170141183460469231731687303715884105728", New(int128.Int128{H: -1 << 63, L: 0}, 0)},
+		{"-1.70141183460469231731687303715884105728", New(int128.Int128{H: -1 << 63, L: 0}, 38)},
+	}
+
+	for _, tc := range testCases {
+		got, err := Parse(tc.s)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.s, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q: want %#v, got %#v", tc.s, tc.want, got)
+		}
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want error
+	}{
+		{"", ErrSyntax},
+		{"-", ErrSyntax},
+		{".", ErrSyntax},
+		{"1..2", ErrSyntax},
+		{"1.2.3", ErrSyntax},
+		{"1a", ErrSyntax},
+		{"1e", ErrSyntax},
+		{"1e+", ErrSyntax},
+		{"1e1.5", ErrSyntax},
+		{"0x10", ErrSyntax},
+		{"170141183460469231731687303715884105728", ErrRange},
+		{"-170141183460469231731687303715884105729", ErrRange},
+		{"340282366920938463463374607431768211456", ErrRange},
+		{"0.000000000000000000000000000000000000001", ErrRange},
+		{"1e39", ErrRange},
+		{"1e-39", ErrRange},
+		{"1e99999999999999999999", ErrRange},
+	}
+
+	for _, tc := range testCases {
+		_, err := Parse(tc.s)
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%q: want %v, got %v", tc.s, tc.want, err)
+		}
+	}
+
+	// the errors are shared with the int128 package.
+	var u int128.Uint128
+	if err := u.UnmarshalText([]byte("1a")); !errors.Is(err, ErrSyntax) {
+		t.Errorf("want %v, got %v", ErrSyntax, err)
+	}
+	if err := u.UnmarshalText([]byte("340282366920938463463374607431768211456")); !errors.Is(err, ErrRange) {
+		t.Errorf("want %v, got %v", ErrRange, err)
+	}
+	if _, err := Parse("1a"); !errors.Is(err, int128.ErrSyntax) {
+		t.Errorf("want %v, got %v", int128.ErrSyntax, err)
+	}
+}
+
+func TestParse_RoundTripQuick(t *testing.T) {
+	f := func(coef int128.Int128, shift, scale uint8) bool {
+		d := randDecimal(coef, shift, scale)
+		got, err := Parse(d.String())
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		return got == d
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkDecimal_String(b *testing.B) {
+	x := mustParse("12345.678901234567890123")
+	for i := 0; i < b.N; i++ {
+		_ = x.String()
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse("12345.678901234567890123")
+	}
+}
@@ -0,0 +1,64 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MarshalText implements [encoding.TextMarshaler].
+func (a Decimal) MarshalText() ([]byte, error) {
+	return a.Append(nil), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (a *Decimal) UnmarshalText(text []byte) error {
+	d, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*a = d
+	return nil
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+// a is encoded as a JSON number.
+func (a Decimal) MarshalJSON() ([]byte, error) {
+	return a.Append(nil), nil
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+// It accepts both a JSON number and a JSON string.
+func (a *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	return a.UnmarshalText(data)
+}
+
+// Value implements [database/sql/driver.Valuer].
+// a is stored as a string to preserve the precision.
+func (a Decimal) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan implements [database/sql.Scanner].
+func (a *Decimal) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return a.UnmarshalText([]byte(src))
+	case []byte:
+		return a.UnmarshalText(src)
+	case int64:
+		return a.UnmarshalText(strconv.AppendInt(nil, src, 10))
+	case float64:
+		return a.UnmarshalText(strconv.AppendFloat(nil, src, 'g', -1, 64))
+	case nil:
+		return errors.New("decimal: cannot scan NULL into Decimal")
+	}
+	return fmt.Errorf("decimal: cannot scan type %T into Decimal", src)
+}
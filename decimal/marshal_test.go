@@ -0,0 +1,104 @@
+package decimal
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+)
+
+var _ = json.Marshaler(Decimal{})
+var _ = json.Unmarshaler(&Decimal{})
+var _ = encoding.TextMarshaler(Decimal{})
+var _ = encoding.TextUnmarshaler(&Decimal{})
+var _ = driver.Valuer(Decimal{})
+var _ = sql.Scanner(&Decimal{})
+
+func TestDecimal_MarshalJSON(t *testing.T) {
+	v := struct {
+		Amount Decimal `json:"amount"`
+	}{
+		Amount: mustParse("-1234567890.123456789012345678"),
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"amount":-1234567890.123456789012345678}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		data string
+		want string
+	}{
+		{`{"amount":-1234567890.123456789012345678}`, "-1234567890.123456789012345678"},
+		{`{"amount":"0.10"}`, "0.10"},
+		{`{"amount":1e2}`, "100"},
+		{`{"amount":null}`, "0"},
+	}
+
+	for _, tc := range testCases {
+		var v struct {
+			Amount Decimal `json:"amount"`
+		}
+		if err := json.Unmarshal([]byte(tc.data), &v); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.data, err)
+			continue
+		}
+		if v.Amount.String() != tc.want {
+			t.Errorf("%s: want %s, got %s", tc.data, tc.want, v.Amount)
+		}
+	}
+
+	var v struct {
+		Amount Decimal `json:"amount"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"1.2.3"}`), &v); err == nil {
+		t.Error("want error, but not")
+	}
+}
+
+func TestDecimal_Value(t *testing.T) {
+	v, err := mustParse("-12.345").Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "-12.345" {
+		t.Errorf("want %q, got %#v", "-12.345", v)
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	testCases := []struct {
+		src  interface{}
+		want string
+	}{
+		{"123.4500", "123.4500"},
+		{[]byte("-0.001"), "-0.001"},
+		{int64(-42), "-42"},
+		{float64(1.25), "1.25"},
+	}
+
+	for _, tc := range testCases {
+		var d Decimal
+		if err := d.Scan(tc.src); err != nil {
+			t.Errorf("%#v: unexpected error: %v", tc.src, err)
+			continue
+		}
+		if d.String() != tc.want {
+			t.Errorf("%#v: want %s, got %s", tc.src, tc.want, d)
+		}
+	}
+
+	var d Decimal
+	if err := d.Scan(nil); err == nil {
+		t.Error("want error, but not")
+	}
+	if err := d.Scan(true); err == nil {
+		t.Error("want error, but not")
+	}
+}
@@ -0,0 +1,147 @@
+package int128
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// derTagInteger is the tag of ASN.1 INTEGER.
+const derTagInteger = 0x02
+
+var (
+	errDERSyntax     = errors.New("int128: invalid DER INTEGER")
+	errDERNonMinimal = errors.New("int128: DER INTEGER is not minimally encoded")
+	errDERRange      = errors.New("int128: DER INTEGER out of range")
+)
+
+// MarshalDER returns the DER encoding of a as an ASN.1 INTEGER, including the tag and the length.
+// The content is the minimal two's complement representation of a,
+// so it may be 17 bytes long with a leading 0x00.
+// The result is the same as the one of encoding/asn1.Marshal with the equivalent *big.Int.
+func (a Uint128) MarshalDER() ([]byte, error) {
+	var buf [17]byte
+	binary.BigEndian.PutUint64(buf[1:9], a.H)
+	binary.BigEndian.PutUint64(buf[9:], a.L)
+	return appendDER(nil, &buf), nil
+}
+
+// ParseDER parses the DER encoded ASN.1 INTEGER, such as the serial number of X.509 certificates, into a.
+// It accepts the encodings that encoding/asn1 and golang.org/x/crypto/cryptobyte accept:
+// the length and the content must be minimally encoded.
+// Negative values and trailing data are rejected.
+func (a *Uint128) ParseDER(der []byte) error {
+	content, err := parseDER(der)
+	if err != nil {
+		return err
+	}
+	if content[0]&0x80 != 0 {
+		// negative
+		return errDERRange
+	}
+	if len(content) == 17 && content[0] == 0x00 {
+		// the leading 0x00 for the values whose most significant bit is set.
+		content = content[1:]
+	}
+	if len(content) > 16 {
+		return errDERRange
+	}
+
+	var buf [16]byte
+	copy(buf[16-len(content):], content)
+	*a = Uint128{binary.BigEndian.Uint64(buf[:8]), binary.BigEndian.Uint64(buf[8:])}
+	return nil
+}
+
+// MarshalDER returns the DER encoding of a as an ASN.1 INTEGER, including the tag and the length.
+// The content is the minimal two's complement representation of a.
+// The result is the same as the one of encoding/asn1.Marshal with the equivalent *big.Int.
+func (a Int128) MarshalDER() ([]byte, error) {
+	var buf [17]byte
+	buf[0] = byte(a.H >> 63)
+	binary.BigEndian.PutUint64(buf[1:9], uint64(a.H))
+	binary.BigEndian.PutUint64(buf[9:], a.L)
+	return appendDER(nil, &buf), nil
+}
+
+// ParseDER parses the DER encoded ASN.1 INTEGER into a.
+// It accepts the encodings that encoding/asn1 and golang.org/x/crypto/cryptobyte accept:
+// the length and the content must be minimally encoded.
+// Trailing data is rejected.
+func (a *Int128) ParseDER(der []byte) error {
+	content, err := parseDER(der)
+	if err != nil {
+		return err
+	}
+	if len(content) > 16 {
+		return errDERRange
+	}
+
+	var buf [16]byte
+	if content[0]&0x80 != 0 {
+		// sign extension
+		buf = [16]byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		}
+	}
+	copy(buf[16-len(content):], content)
+	*a = Int128{int64(binary.BigEndian.Uint64(buf[:8])), binary.BigEndian.Uint64(buf[8:])}
+	return nil
+}
+
+// appendDER appends the INTEGER whose 136-bit two's complement representation is buf.
+func appendDER(dst []byte, buf *[17]byte) []byte {
+	// trim the redundant leading bytes.
+	i := 0
+	for i < len(buf)-1 {
+		if buf[i] == 0x00 && buf[i+1]&0x80 == 0 || buf[i] == 0xff && buf[i+1]&0x80 != 0 {
+			i++
+			continue
+		}
+		break
+	}
+	content := buf[i:]
+	dst = append(dst, derTagInteger, byte(len(content)))
+	return append(dst, content...)
+}
+
+// parseDER parses the INTEGER in der, and returns its content.
+// The content is not empty, and it is minimally encoded.
+func parseDER(der []byte) ([]byte, error) {
+	if len(der) < 2 || der[0] != derTagInteger {
+		return nil, errDERSyntax
+	}
+	length, content := int(der[1]), der[2:]
+	if length&0x80 != 0 {
+		// the long form
+		n := length & 0x7f
+		if n == 0 || n == 0x7f || n > 4 {
+			// indefinite length, reserved, or too long
+			return nil, errDERSyntax
+		}
+		if len(content) < n {
+			return nil, errDERSyntax
+		}
+		if content[0] == 0 {
+			return nil, errDERNonMinimal
+		}
+		length = 0
+		for _, c := range content[:n] {
+			length = length<<8 | int(c)
+		}
+		if length < 0x80 {
+			// it must be encoded in the short form.
+			return nil, errDERNonMinimal
+		}
+		content = content[n:]
+	}
+	if length != len(content) || length == 0 {
+		return nil, errDERSyntax
+	}
+	if len(content) > 1 {
+		if content[0] == 0x00 && content[1]&0x80 == 0 || content[0] == 0xff && content[1]&0x80 != 0 {
+			return nil, errDERNonMinimal
+		}
+	}
+	return content, nil
+}
@@ -0,0 +1,244 @@
+package int128
+
+import (
+	"encoding/asn1"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestUint128_MarshalDER(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		want string
+	}{
+		{Uint128{0, 0}, "020100"},
+		{Uint128{0, 1}, "020101"},
+		{Uint128{0, 0x7f}, "02017f"},
+		{Uint128{0, 0x80}, "02020080"},
+		{Uint128{0, 0x0100}, "02020100"},
+		{Uint128{0, math.MaxUint64}, "020900ffffffffffffffff"},
+		{Uint128{1, 0}, "0209010000000000000000"},
+		{Uint128{math.MaxInt64, math.MaxUint64}, "02107fffffffffffffffffffffffffffffff"},
+		{Uint128{1 << 63, 0}, "02110080000000000000000000000000000000"},
+		{Uint128{math.MaxUint64, math.MaxUint64}, "021100ffffffffffffffffffffffffffffffff"},
+	}
+
+	for i, tc := range testCases {
+		got, err := tc.a.MarshalDER()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Errorf("%d: %#v should %s, but %x", i, tc.a, tc.want, got)
+		}
+
+		data, _ := hex.DecodeString(tc.want)
+		var a Uint128
+		if err := a.ParseDER(data); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if a != tc.a {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.want, tc.a, a)
+		}
+	}
+}
+
+func TestInt128_MarshalDER(t *testing.T) {
+	testCases := []struct {
+		a    Int128
+		want string
+	}{
+		{Int128{0, 0}, "020100"},
+		{Int128{0, 0x7f}, "02017f"},
+		{Int128{0, 0x80}, "02020080"},
+		{Int128{-1, math.MaxUint64}, "0201ff"},
+		{Int128{-1, math.MaxUint64 - 0x7f}, "020180"},
+		{Int128{-1, math.MaxUint64 - 0x80}, "0202ff7f"},
+		{Int128{-1, 0}, "0209ff0000000000000000"},
+		{Int128{-1, 1 << 63}, "02088000000000000000"},
+		{Int128{math.MaxInt64, math.MaxUint64}, "02107fffffffffffffffffffffffffffffff"},
+		{Int128{math.MinInt64, 0}, "021080000000000000000000000000000000"},
+	}
+
+	for i, tc := range testCases {
+		got, err := tc.a.MarshalDER()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Errorf("%d: %#v should %s, but %x", i, tc.a, tc.want, got)
+		}
+
+		data, _ := hex.DecodeString(tc.want)
+		var a Int128
+		if err := a.ParseDER(data); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if a != tc.a {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.want, tc.a, a)
+		}
+	}
+}
+
+func TestParseDER_Error(t *testing.T) {
+	testCases := []struct {
+		data string
+		uerr error // the error from Uint128.ParseDER
+		err  error // the error from Int128.ParseDER
+	}{
+		{"", errDERSyntax, errDERSyntax},
+		{"02", errDERSyntax, errDERSyntax},
+		{"0200", errDERSyntax, errDERSyntax},
+		{"020201", errDERSyntax, errDERSyntax},
+		{"02010000", errDERSyntax, errDERSyntax}, // trailing data
+		{"030100", errDERSyntax, errDERSyntax},   // BIT STRING
+		{"0a0100", errDERSyntax, errDERSyntax},   // ENUMERATED
+		{"028000", errDERSyntax, errDERSyntax},   // indefinite length
+		{"0282", errDERSyntax, errDERSyntax},
+
+		// non-minimal encodings
+		{"02020001", errDERNonMinimal, errDERNonMinimal},
+		{"0202ffff", errDERNonMinimal, errDERNonMinimal},
+		{"0203000080", errDERNonMinimal, errDERNonMinimal},
+		{"02810101", errDERNonMinimal, errDERNonMinimal},
+		{"0282000101", errDERNonMinimal, errDERNonMinimal},
+
+		// out of range
+		{"0201ff", errDERRange, nil},
+		{"021080000000000000000000000000000000", errDERRange, nil},
+		{"021100ffffffffffffffffffffffffffffffff", nil, errDERRange},
+		{"021101" + "00000000000000000000000000000000", errDERRange, errDERRange},
+		{"0211ff00000000000000000000000000000000", errDERRange, errDERRange},
+		{"0281800100" + "000000000000000000000000000000000000000000000000000000000000" +
+			"0000000000000000000000000000000000000000000000000000000000000000" +
+			"0000000000000000000000000000000000000000000000000000000000000000" +
+			"0000000000000000000000000000000000000000000000000000000000000000", errDERRange, errDERRange},
+	}
+
+	for i, tc := range testCases {
+		data, err := hex.DecodeString(tc.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u Uint128
+		if err := u.ParseDER(data); err != tc.uerr {
+			t.Errorf("%d: Uint128.ParseDER(%s) should %v, but %v", i, tc.data, tc.uerr, err)
+		}
+		var s Int128
+		if err := s.ParseDER(data); err != tc.err {
+			t.Errorf("%d: Int128.ParseDER(%s) should %v, but %v", i, tc.data, tc.err, err)
+		}
+	}
+}
+
+func TestUint128_MarshalDERQuick(t *testing.T) {
+	f := func(a Uint128, shift uint8) []byte {
+		data, err := a.Rsh(uint(shift % 128)).MarshalDER()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	g := func(a Uint128, shift uint8) []byte {
+		data, err := asn1.Marshal(uint128ToBig(new(big.Int), a.Rsh(uint(shift%128))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_MarshalDERQuick(t *testing.T) {
+	f := func(a Int128, shift uint8) []byte {
+		data, err := a.Rsh(uint(shift % 128)).MarshalDER()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	g := func(a Int128, shift uint8) []byte {
+		data, err := asn1.Marshal(int128ToBig(new(big.Int), a.Rsh(uint(shift%128))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseDER_Asn1(t *testing.T) {
+	// X.509 serial numbers are usually unmarshaled into *big.Int.
+	type tbsCertificate struct {
+		Version      int `asn1:"optional,explicit,default:0,tag:0"`
+		SerialNumber *big.Int
+	}
+	want := Uint128{0x0123_4567_89ab_cdef, 0xfedc_ba98_7654_3210}
+	data, err := asn1.Marshal(tbsCertificate{Version: 2, SerialNumber: uint128ToBig(new(big.Int), want)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw struct {
+		Version      asn1.RawValue `asn1:"optional,explicit,tag:0"`
+		SerialNumber asn1.RawValue
+	}
+	if _, err := asn1.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	var got Uint128
+	if err := got.ParseDER(raw.SerialNumber.FullBytes); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+func FuzzParseDER(f *testing.F) {
+	f.Add([]byte{0x02, 0x01, 0x00})
+	f.Add([]byte{0x02, 0x02, 0x00, 0x80})
+	f.Add([]byte{0x02, 0x09, 0xff, 0, 0, 0, 0, 0, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// ParseDER must agree with encoding/asn1.
+		var b *big.Int
+		rest, err := asn1.Unmarshal(data, &b)
+		ok := err == nil && len(rest) == 0
+
+		var u Uint128
+		uerr := u.ParseDER(data)
+		if ok && b.Sign() >= 0 && b.BitLen() <= 128 {
+			if uerr != nil {
+				t.Errorf("%x: unexpected error: %v", data, uerr)
+			} else if uint128ToBig(new(big.Int), u).Cmp(b) != 0 {
+				t.Errorf("%x: want %s, got %s", data, b, u)
+			}
+		} else if uerr == nil {
+			t.Errorf("%x: want error, got %s", data, u)
+		}
+
+		var s Int128
+		serr := s.ParseDER(data)
+		if ok && b.Cmp(int128ToBig(new(big.Int), Int128{math.MinInt64, 0})) >= 0 && b.Cmp(int128ToBig(new(big.Int), Int128{math.MaxInt64, math.MaxUint64})) <= 0 {
+			if serr != nil {
+				t.Errorf("%x: unexpected error: %v", data, serr)
+			} else if int128ToBig(new(big.Int), s).Cmp(b) != 0 {
+				t.Errorf("%x: want %s, got %s", data, b, s)
+			}
+		} else if serr == nil {
+			t.Errorf("%x: want error, got %s", data, s)
+		}
+	})
+}
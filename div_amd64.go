@@ -0,0 +1,13 @@
+//go:build !purego
+
+package int128
+
+// divmod128 returns the quotient and the remainder of (aH, aL) / (bH, bL).
+// It is implemented in div_amd64.s.
+func divmod128(aH, aL, bH, bL uint64) (qH, qL, rH, rL uint64)
+
+// divmodPow10 returns the quotient and the remainder of a / 10**n.
+// DIVQ is faster than the multiplication by the reciprocal on amd64.
+func divmodPow10(a Uint128, n uint) (q, r Uint128) {
+	return a.DivMod(pow10tab[n])
+}
@@ -0,0 +1,104 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// func divmod128(aH, aL, bH, bL uint64) (qH, qL, rH, rL uint64)
+TEXT ·divmod128(SB), NOSPLIT, $0-64
+	MOVQ aH+0(FP), R8
+	MOVQ aL+8(FP), R9
+	MOVQ bH+16(FP), R10
+	MOVQ bL+24(FP), R11
+	TESTQ R10, R10
+	JNZ  large
+
+	// the divisor fits in uint64.
+	CMPQ R8, R11
+	JCS  by64_small
+
+	// the quotient doesn't fit in uint64; divide twice.
+	// DIVQ raises the divide error if the divisor is zero,
+	// and the Go runtime turns it into a panic.
+	MOVQ R8, AX
+	XORL DX, DX
+	DIVQ R11
+	MOVQ AX, qH+32(FP)
+	MOVQ R9, AX
+	DIVQ R11
+	MOVQ AX, qL+40(FP)
+	MOVQ $0, rH+48(FP)
+	MOVQ DX, rL+56(FP)
+	RET
+
+by64_small:
+	// aH < bL, so the quotient fits in uint64.
+	MOVQ R8, DX
+	MOVQ R9, AX
+	DIVQ R11
+	MOVQ $0, qH+32(FP)
+	MOVQ AX, qL+40(FP)
+	MOVQ $0, rH+48(FP)
+	MOVQ DX, rL+56(FP)
+	RET
+
+large:
+	// the divisor doesn't fit in uint64, so the quotient fits in uint64.
+	CMPQ R8, R10
+	JCS  zero
+
+	// n = the number of leading zeros of bH
+	BSRQ R10, CX
+	XORQ $63, CX
+
+	// BX = the upper half of b << n
+	MOVQ R10, BX
+	SHLQ CX, R11, BX
+
+	// DX:AX = a >> 1
+	MOVQ R9, AX
+	SHRQ $1, R8, AX
+	MOVQ R8, DX
+	SHRQ $1, DX
+
+	// q = ((a >> 1) / (b << n).H) >> (63 - n)
+	// the estimate is q or q+1 of the true quotient.
+	DIVQ BX
+	XORQ $63, CX
+	SHRQ CX, AX
+
+	// q = max(q-1, 0)
+	SUBQ $1, AX
+	ADCQ $0, AX
+	MOVQ AX, R12
+
+	// R8:R9 = a - q*b
+	MULQ R11
+	MOVQ R10, R13
+	IMULQ R12, R13
+	ADDQ R13, DX
+	SUBQ AX, R9
+	SBBQ DX, R8
+
+	// if r >= b { q++; r -= b }
+	MOVQ R9, AX
+	MOVQ R8, DX
+	SUBQ R11, AX
+	SBBQ R10, DX
+	JCS  large_done
+	INCQ R12
+	MOVQ AX, R9
+	MOVQ DX, R8
+
+large_done:
+	MOVQ $0, qH+32(FP)
+	MOVQ R12, qL+40(FP)
+	MOVQ R8, rH+48(FP)
+	MOVQ R9, rL+56(FP)
+	RET
+
+zero:
+	// a < b
+	MOVQ $0, qH+32(FP)
+	MOVQ $0, qL+40(FP)
+	MOVQ R8, rH+48(FP)
+	MOVQ R9, rL+56(FP)
+	RET
@@ -0,0 +1,38 @@
+package int128
+
+import "math/bits"
+
+// divmod128Generic returns the quotient and the remainder of (aH, aL) / (bH, bL).
+// It is the pure Go implementation of divmod128.
+func divmod128Generic(aH, aL, bH, bL uint64) (qH, qL, rH, rL uint64) {
+	if bH == 0 {
+		// optimize for uint128 / uint64
+		if aH < bL {
+			qL, rL = bits.Div64(aH, aL, bL)
+			return 0, qL, 0, rL
+		}
+		qH = aH / bL
+		qL, rL = bits.Div64(aH%bL, aL, bL)
+		return qH, qL, 0, rL
+	}
+
+	a := Uint128{aH, aL}
+	b := Uint128{bH, bL}
+	n := uint(bits.LeadingZeros64(b.H))
+	x := a.Rsh(1)
+	y := b.Lsh(n)
+	q, _ := bits.Div64(x.H, x.L, y.H)
+	q >>= 63 - n
+	if q > 0 {
+		q--
+	}
+
+	h, l := bits.Mul64(b.L, q)
+	h += b.H * q
+	r := a.Sub(Uint128{h, l})
+	if r.Cmp(b) >= 0 {
+		q++
+		r = r.Sub(b)
+	}
+	return 0, q, r.H, r.L
+}
@@ -0,0 +1,29 @@
+//go:build !amd64 || purego
+
+package int128
+
+import "sync"
+
+// divmod128 returns the quotient and the remainder of (aH, aL) / (bH, bL).
+func divmod128(aH, aL, bH, bL uint64) (qH, qL, rH, rL uint64) {
+	return divmod128Generic(aH, aL, bH, bL)
+}
+
+var (
+	pow10dividersOnce sync.Once
+
+	// pow10dividers[n] divides by 10**n.
+	pow10dividers [len(pow10tab)]Divider
+)
+
+// divmodPow10 returns the quotient and the remainder of a / 10**n.
+// bits.Div64 is implemented in software on the architectures other than amd64,
+// so it divides by multiplying with the reciprocal of 10**n.
+func divmodPow10(a Uint128, n uint) (q, r Uint128) {
+	pow10dividersOnce.Do(func() {
+		for i, p := range pow10tab {
+			pow10dividers[i] = NewDivider(p)
+		}
+	})
+	return pow10dividers[n].DivMod(a)
+}
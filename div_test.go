@@ -0,0 +1,88 @@
+package int128
+
+import (
+	"math/big"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+func TestDivmod128Quick(t *testing.T) {
+	f := func(aH, aL, bH, bL uint64, shift uint8) [4]uint64 {
+		// test the divisors of various lengths.
+		b := Uint128{bH, bL}.Rsh(uint(shift % 128))
+		if b.H == 0 && b.L == 0 {
+			return [4]uint64{}
+		}
+		qH, qL, rH, rL := divmod128(aH, aL, b.H, b.L)
+		return [4]uint64{qH, qL, rH, rL}
+	}
+	g := func(aH, aL, bH, bL uint64, shift uint8) [4]uint64 {
+		b := Uint128{bH, bL}.Rsh(uint(shift % 128))
+		if b.H == 0 && b.L == 0 {
+			return [4]uint64{}
+		}
+		q, r := new(big.Int).QuoRem(uint128ToBig(nil, Uint128{aH, aL}), uint128ToBig(nil, b), new(big.Int))
+		bq, br := bigToUint128(q), bigToUint128(r)
+		return [4]uint64{bq.H, bq.L, br.H, br.L}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzDivmod128(f *testing.F) {
+	f.Add(uint64(0), uint64(0), uint64(0), uint64(1))
+	f.Add(uint64(1), uint64(0), uint64(0), uint64(1))
+	f.Add(uint64(0xffff_ffff_ffff_ffff), uint64(0xffff_ffff_ffff_ffff), uint64(0), uint64(0xffff_ffff_ffff_ffff))
+	f.Add(uint64(0xffff_ffff_ffff_ffff), uint64(0xffff_ffff_ffff_ffff), uint64(1), uint64(0))
+	f.Add(uint64(0x8000_0000_0000_0000), uint64(0), uint64(0x8000_0000_0000_0000), uint64(1))
+	f.Add(uint64(0x1234_5678_9abc_def0), uint64(0x1234_5678_9abc_def0), uint64(0x1234), uint64(0x5678_9abc_def0_1234))
+	f.Fuzz(func(t *testing.T, aH, aL, bH, bL uint64) {
+		if bH == 0 && bL == 0 {
+			return
+		}
+
+		// the assembly implementation must be bit-identical with the pure Go one.
+		qH, qL, rH, rL := divmod128(aH, aL, bH, bL)
+		gqH, gqL, grH, grL := divmod128Generic(aH, aL, bH, bL)
+		if qH != gqH || qL != gqL || rH != grH || rL != grL {
+			t.Errorf("divmod128(%#x, %#x, %#x, %#x) = (%#x, %#x, %#x, %#x), want (%#x, %#x, %#x, %#x)",
+				aH, aL, bH, bL, qH, qL, rH, rL, gqH, gqL, grH, grL)
+		}
+
+		// q*b + r == a and r < b
+		q, r, b := Uint128{qH, qL}, Uint128{rH, rL}, Uint128{bH, bL}
+		if q.Mul(b).Add(r) != (Uint128{aH, aL}) || r.Cmp(b) >= 0 {
+			t.Errorf("%#v / %#v = %#v, %#v is wrong", Uint128{aH, aL}, b, q, r)
+		}
+	})
+}
+
+func TestDivmod128_DivideByZero(t *testing.T) {
+	for _, a := range []Uint128{{0, 0}, {0, 1}, {1, 0}} {
+		func() {
+			defer func() {
+				err := recover()
+				if _, ok := err.(runtime.Error); !ok {
+					t.Errorf("%#v / 0: want runtime.Error, got %#v", a, err)
+				}
+			}()
+			a.DivMod(Uint128{})
+		}()
+	}
+}
+
+func BenchmarkDivmod128Generic(b *testing.B) {
+	x := Uint128{0x1234_5678_9abc_def0, 0x1234_5678_9abc_def0}
+	y := Uint128{0x1234, 0x5678_9abc_def0_1234}
+	for i := 0; i < b.N; i++ {
+		qH, qL, rH, rL := divmod128Generic(x.H, x.L, y.H, y.L)
+		runtime.KeepAlive(qH)
+		runtime.KeepAlive(qL)
+		runtime.KeepAlive(rH)
+		runtime.KeepAlive(rL)
+	}
+}
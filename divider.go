@@ -0,0 +1,169 @@
+package int128
+
+// Divider divides Uint128 values by a fixed divisor.
+// It replaces the division with a multiplication by a precomputed
+// inverse and a shift, as described in Granlund and Montgomery,
+// "Division by Invariant Integers using Multiplication" (1994).
+// The algorithm to compute the inverse follows libdivide.
+//
+// Creating a Divider is as expensive as a few divisions,
+// so it pays off only when the same divisor is used repeatedly.
+// The gain also depends on the CPU: it is large on CPUs without fast
+// 128-bit by 64-bit division, but recent amd64 CPUs divide
+// almost as fast as Divider multiplies.
+// The zero value is not usable; use NewDivider instead.
+type Divider struct {
+	d     Uint128
+	magic Uint128
+	shift uint
+	add   bool // the magic number needs 129 bits
+	pow2  bool // d is a power of two; the quotient is a>>shift
+}
+
+// NewDivider returns a Divider that divides by d.
+// If d == 0, a division-by-zero run-time panic occurs.
+func NewDivider(d Uint128) Divider {
+	if d.H == 0 && d.L == 0 {
+		panic("int128: division by zero")
+	}
+
+	l := uint(d.Len() - 1) // floor(log2(d))
+	if d.IsPowerOfTwo() {
+		return Divider{d: d, shift: l, pow2: true}
+	}
+
+	// m = floor(2**(128+l) / d)
+	// 2**l < d, so the quotient fits in Uint128.
+	m, rem := div128(Uint128{0, 1}.Lsh(l), Uint128{}, d)
+	e := d.Sub(rem)
+	if e.Cmp(Uint128{0, 1}.Lsh(l)) < 0 {
+		// 2**l is enough for the magic number.
+		return Divider{d: d, magic: m.Add(Uint128{0, 1}), shift: l}
+	}
+
+	// we need 2**(l+1), so the magic number has 129 bits.
+	// the highest bit is emulated by the add-and-shift in div.
+	m = m.Add(m)
+	twice := rem.Add(rem)
+	if twice.Cmp(d) >= 0 || twice.Cmp(rem) < 0 {
+		m = m.Add(Uint128{0, 1})
+	}
+	return Divider{d: d, magic: m.Add(Uint128{0, 1}), shift: l, add: true}
+}
+
+// Divisor returns the divisor of d.
+func (d Divider) Divisor() Uint128 {
+	return d.d
+}
+
+// Div returns the quotient a/d.
+func (d Divider) Div(a Uint128) Uint128 {
+	if d.pow2 {
+		return a.Rsh(d.shift)
+	}
+	q, _ := mul128(a, d.magic)
+	if d.add {
+		// (a + q) / 2 without overflow
+		t := a.Sub(q).Rsh(1).Add(q)
+		return t.Rsh(d.shift)
+	}
+	return q.Rsh(d.shift)
+}
+
+// Mod returns the remainder a%d.
+func (d Divider) Mod(a Uint128) Uint128 {
+	_, mod := d.DivMod(a)
+	return mod
+}
+
+// DivMod returns the quotient a/d and the remainder a%d.
+func (d Divider) DivMod(a Uint128) (Uint128, Uint128) {
+	if d.pow2 {
+		return a.Rsh(d.shift), a.And(d.d.Sub(Uint128{0, 1}))
+	}
+	q, _ := mul128(a, d.magic)
+	if d.add {
+		q = a.Sub(q).Rsh(1).Add(q)
+	}
+	q = q.Rsh(d.shift)
+	return q, a.Sub(q.Mul(d.d))
+}
+
+// Int128Divider divides Int128 values by a fixed divisor.
+// See Divider for details.
+// The zero value is not usable; use NewInt128Divider instead.
+type Int128Divider struct {
+	d   Int128
+	abs Divider
+}
+
+// NewInt128Divider returns an Int128Divider that divides by d.
+// If d == 0, a division-by-zero run-time panic occurs.
+func NewInt128Divider(d Int128) Int128Divider {
+	return Int128Divider{d: d, abs: NewDivider(d.AbsUint128())}
+}
+
+// Divisor returns the divisor of d.
+func (d Int128Divider) Divisor() Int128 {
+	return d.d
+}
+
+// Div returns the quotient a/d.
+// Div implements Euclidean division like Int128.Div.
+func (d Int128Divider) Div(a Int128) Int128 {
+	div, _ := d.DivMod(a)
+	return div
+}
+
+// Mod returns the modulus a%d.
+// Mod implements Euclidean modulus like Int128.Mod.
+func (d Int128Divider) Mod(a Int128) Int128 {
+	_, mod := d.DivMod(a)
+	return mod
+}
+
+// DivMod returns the quotient and remainder of a/d.
+// DivMod implements Euclidean division and modulus like Int128.DivMod.
+func (d Int128Divider) DivMod(a Int128) (Int128, Int128) {
+	negA, negB := a.IsNeg(), d.d.IsNeg()
+	div, mod := d.abs.DivMod(a.AbsUint128())
+	if negA && (mod.H != 0 || mod.L != 0) {
+		// round the quotient away from zero so that the modulus is positive.
+		mod = d.abs.d.Sub(mod)
+		div = div.Add(Uint128{0, 1})
+	}
+	if negA != negB {
+		div = div.Neg()
+	}
+	return div.Int128(), mod.Int128()
+}
+
+// Quo returns the quotient a/d.
+// Quo implements truncated division like Int128.Quo.
+func (d Int128Divider) Quo(a Int128) Int128 {
+	div := d.abs.Div(a.AbsUint128())
+	if a.IsNeg() != d.d.IsNeg() {
+		div = div.Neg()
+	}
+	return div.Int128()
+}
+
+// Rem returns the remainder a%d.
+// Rem implements truncated modulus like Int128.Rem.
+func (d Int128Divider) Rem(a Int128) Int128 {
+	_, mod := d.QuoRem(a)
+	return mod
+}
+
+// QuoRem returns the quotient a/d and the remainder a%d.
+// QuoRem implements T-division and modulus like Int128.QuoRem.
+func (d Int128Divider) QuoRem(a Int128) (Int128, Int128) {
+	div, mod := d.abs.DivMod(a.AbsUint128())
+	if a.IsNeg() != d.d.IsNeg() {
+		div = div.Neg()
+	}
+	if a.IsNeg() {
+		mod = mod.Neg()
+	}
+	return div.Int128(), mod.Int128()
+}
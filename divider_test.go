@@ -0,0 +1,232 @@
+package int128
+
+import (
+	"math"
+	"math/big"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+func TestDivider(t *testing.T) {
+	max := Uint128{math.MaxUint64, math.MaxUint64}
+	dividends := []Uint128{
+		{0, 0},
+		{0, 1},
+		{0, 42},
+		{0, math.MaxUint64},
+		{1, 0},
+		{0x1234_5678_9abc_def0, 0x1234_5678_9abc_def0},
+		{math.MaxInt64, math.MaxUint64},
+		{1 << 63, 0},
+		max.Sub(Uint128{0, 1}),
+		max,
+	}
+
+	// the divisors around the powers of two are the corner cases of the magic numbers.
+	var divisors []Uint128
+	for i := uint(0); i < 128; i++ {
+		p := Uint128{0, 1}.Lsh(i)
+		divisors = append(divisors, p, p.Add(Uint128{0, 1}), p.Sub(Uint128{0, 1}), p.Mul(Uint128{0, 3}))
+	}
+	divisors = append(divisors, Pow10Uint128(19), Pow10Uint128(38), max)
+
+	for _, d := range divisors {
+		if d.H == 0 && d.L == 0 {
+			continue
+		}
+		div := NewDivider(d)
+		for _, a := range dividends {
+			wantQ, wantR := a.DivMod(d)
+			q, r := div.DivMod(a)
+			if q != wantQ || r != wantR {
+				t.Errorf("%#v / %#v: want (%#v, %#v), got (%#v, %#v)", a, d, wantQ, wantR, q, r)
+			}
+		}
+	}
+}
+
+func TestDividerQuick(t *testing.T) {
+	f := func(a, b Uint128, shift uint8) [2]Uint128 {
+		// test the divisors of various lengths.
+		b = b.Rsh(uint(shift % 128))
+		if b.H == 0 && b.L == 0 {
+			return [2]Uint128{}
+		}
+		q, r := NewDivider(b).DivMod(a)
+		return [2]Uint128{q, r}
+	}
+	g := func(a, b Uint128, shift uint8) [2]Uint128 {
+		b = b.Rsh(uint(shift % 128))
+		if b.H == 0 && b.L == 0 {
+			return [2]Uint128{}
+		}
+		q, r := a.DivMod(b)
+		return [2]Uint128{q, r}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzDivider(f *testing.F) {
+	f.Add(uint64(0), uint64(0), uint64(0), uint64(1))
+	f.Add(uint64(0xffff_ffff_ffff_ffff), uint64(0xffff_ffff_ffff_ffff), uint64(0), uint64(3))
+	f.Add(uint64(0xffff_ffff_ffff_ffff), uint64(0xffff_ffff_ffff_ffff), uint64(0), uint64(7))
+	f.Add(uint64(0xffff_ffff_ffff_ffff), uint64(0xffff_ffff_ffff_ffff), uint64(1), uint64(1))
+	f.Add(uint64(0x1234_5678_9abc_def0), uint64(0x1234_5678_9abc_def0), uint64(0x1234), uint64(0x5678_9abc_def0_1234))
+	f.Fuzz(func(t *testing.T, aH, aL, bH, bL uint64) {
+		a, b := Uint128{aH, aL}, Uint128{bH, bL}
+		if b.H == 0 && b.L == 0 {
+			return
+		}
+		wantQ, wantR := a.DivMod(b)
+		q, r := NewDivider(b).DivMod(a)
+		if q != wantQ || r != wantR {
+			t.Errorf("%#v / %#v: want (%#v, %#v), got (%#v, %#v)", a, b, wantQ, wantR, q, r)
+		}
+	})
+}
+
+func TestDivider_DivideByZero(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil {
+			t.Error("NewDivider(0) should panic")
+		}
+	}()
+	NewDivider(Uint128{})
+}
+
+func TestInt128DividerQuick(t *testing.T) {
+	f := func(a, b Int128, shift uint8) [4]Int128 {
+		b = b.Rsh(uint(shift % 128))
+		if b.H == 0 && b.L == 0 {
+			return [4]Int128{}
+		}
+		div := NewInt128Divider(b)
+		q, m := div.DivMod(a)
+		quo, rem := div.QuoRem(a)
+		return [4]Int128{q, m, quo, rem}
+	}
+	g := func(a, b Int128, shift uint8) [4]Int128 {
+		b = b.Rsh(uint(shift % 128))
+		if b.H == 0 && b.L == 0 {
+			return [4]Int128{}
+		}
+		bigA := int128ToBig(new(big.Int), a)
+		bigB := int128ToBig(new(big.Int), b)
+		q, m := new(big.Int).DivMod(bigA, bigB, new(big.Int))
+		quo, rem := new(big.Int).QuoRem(bigA, bigB, new(big.Int))
+		return [4]Int128{bigToInt128(q), bigToInt128(m), bigToInt128(quo), bigToInt128(rem)}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128Divider(t *testing.T) {
+	testCases := []struct {
+		a, b     Int128
+		div, mod Int128
+		quo, rem Int128
+	}{
+		{
+			Int128{0, 7}, Int128{0, 2},
+			Int128{0, 3}, Int128{0, 1},
+			Int128{0, 3}, Int128{0, 1},
+		},
+		{
+			Int128{-1, -7 & math.MaxUint64}, Int128{0, 2},
+			Int128{-1, -4 & math.MaxUint64}, Int128{0, 1},
+			Int128{-1, -3 & math.MaxUint64}, Int128{-1, math.MaxUint64},
+		},
+		{
+			Int128{0, 7}, Int128{-1, -2 & math.MaxUint64},
+			Int128{-1, -3 & math.MaxUint64}, Int128{0, 1},
+			Int128{-1, -3 & math.MaxUint64}, Int128{0, 1},
+		},
+		{
+			Int128{-1, -7 & math.MaxUint64}, Int128{-1, -2 & math.MaxUint64},
+			Int128{0, 4}, Int128{0, 1},
+			Int128{0, 3}, Int128{-1, math.MaxUint64},
+		},
+		{
+			Int128{-1, -6 & math.MaxUint64}, Int128{0, 2},
+			Int128{-1, -3 & math.MaxUint64}, Int128{0, 0},
+			Int128{-1, -3 & math.MaxUint64}, Int128{0, 0},
+		},
+		{
+			// the divisor is MinInt128
+			Int128{math.MinInt64, 0}, Int128{math.MinInt64, 0},
+			Int128{0, 1}, Int128{0, 0},
+			Int128{0, 1}, Int128{0, 0},
+		},
+	}
+
+	for i, tc := range testCases {
+		div := NewInt128Divider(tc.b)
+		if got := div.Div(tc.a); got != tc.div {
+			t.Errorf("%d: %#v div %#v should %#v, but %#v", i, tc.a, tc.b, tc.div, got)
+		}
+		if got := div.Mod(tc.a); got != tc.mod {
+			t.Errorf("%d: %#v mod %#v should %#v, but %#v", i, tc.a, tc.b, tc.mod, got)
+		}
+		if got := div.Quo(tc.a); got != tc.quo {
+			t.Errorf("%d: %#v / %#v should %#v, but %#v", i, tc.a, tc.b, tc.quo, got)
+		}
+		if got := div.Rem(tc.a); got != tc.rem {
+			t.Errorf("%d: %#v %% %#v should %#v, but %#v", i, tc.a, tc.b, tc.rem, got)
+		}
+	}
+}
+
+func BenchmarkDivider_DivMod(b *testing.B) {
+	div := NewDivider(uint128Input)
+	for i := 0; i < b.N; i++ {
+		q, r := div.DivMod(uint128Input)
+		runtime.KeepAlive(q)
+		runtime.KeepAlive(r)
+	}
+}
+
+func BenchmarkDivider_DivModLarge(b *testing.B) {
+	// the divisor doesn't fit in uint64.
+	x := Uint128{0x1234_5678_9abc_def0, 0x1234_5678_9abc_def0}
+	div := NewDivider(Uint128{0x1234, 0x5678_9abc_def0_1234})
+	for i := 0; i < b.N; i++ {
+		q, r := div.DivMod(x)
+		runtime.KeepAlive(q)
+		runtime.KeepAlive(r)
+	}
+}
+
+func BenchmarkDivider_DivModBy64(b *testing.B) {
+	// the divisor fits in uint64, but the quotient doesn't.
+	x := Uint128{0x1234_5678_9abc_def0, 0x1234_5678_9abc_def0}
+	div := NewDivider(Uint128{0, 0x5678_9abc_def0_1234})
+	for i := 0; i < b.N; i++ {
+		q, r := div.DivMod(x)
+		runtime.KeepAlive(q)
+		runtime.KeepAlive(r)
+	}
+}
+
+func BenchmarkNewDivider(b *testing.B) {
+	d := Uint128{0x1234, 0x5678_9abc_def0_1234}
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(NewDivider(d))
+	}
+}
+
+func BenchmarkInt128Divider_DivMod(b *testing.B) {
+	div := NewInt128Divider(int128Input)
+	for i := 0; i < b.N; i++ {
+		q, r := div.DivMod(int128Input)
+		runtime.KeepAlive(q)
+		runtime.KeepAlive(r)
+	}
+}
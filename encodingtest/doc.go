@@ -0,0 +1,4 @@
+// Package encodingtest tests the int128 types with third-party encoding packages.
+//
+// It is a separate module so that the int128 module doesn't depend on them.
+package encodingtest
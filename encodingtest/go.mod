@@ -0,0 +1,12 @@
+module github.com/shogo82148/int128/encodingtest
+
+go 1.18
+
+require (
+	github.com/BurntSushi/toml v1.2.1
+	github.com/shogo82148/int128 v0.2.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+// test the hooks in this tree, they are not released yet.
+replace github.com/shogo82148/int128 => ../
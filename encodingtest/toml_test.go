@@ -0,0 +1,71 @@
+package encodingtest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/shogo82148/int128"
+)
+
+type tomlValues struct {
+	U int128.Uint128 `toml:"u"`
+	I int128.Int128  `toml:"i"`
+}
+
+func TestTOML(t *testing.T) {
+	testCases := []struct {
+		v    tomlValues
+		toml string
+	}{
+		{
+			v:    tomlValues{},
+			toml: "u = 0\ni = 0\n",
+		},
+		{
+			v:    tomlValues{U: int128.Uint128{H: 0, L: 9223372036854775807}, I: int128.Int128{H: -1, L: 9223372036854775808}},
+			toml: "u = 9223372036854775807\ni = -9223372036854775808\n",
+		},
+		{
+			v:    tomlValues{U: int128.Uint128{H: 0, L: 9223372036854775808}, I: int128.Int128{H: -1, L: 9223372036854775807}},
+			toml: "u = \"9223372036854775808\"\ni = \"-9223372036854775809\"\n",
+		},
+		{
+			v:    tomlValues{U: int128.Uint128{H: 18446744073709551615, L: 18446744073709551615}, I: int128.Int128{H: 9223372036854775807, L: 18446744073709551615}},
+			toml: "u = \"340282366920938463463374607431768211455\"\ni = \"170141183460469231731687303715884105727\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(tc.v); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.toml {
+			t.Errorf("want %q, got %q", tc.toml, buf.String())
+		}
+
+		var got tomlValues
+		if _, err := toml.Decode(buf.String(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.v {
+			t.Errorf("want %#v, got %#v", tc.v, got)
+		}
+	}
+}
+
+func TestTOML_Error(t *testing.T) {
+	testCases := []string{
+		"u = -1\n",
+		"u = 1.5\n",
+		"i = true\n",
+		"i = \"170141183460469231731687303715884105728\"\n",
+	}
+	for _, input := range testCases {
+		var got tomlValues
+		if _, err := toml.Decode(input, &got); err == nil {
+			t.Errorf("%q: want error, got nil", input)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package encodingtest
+
+import (
+	"testing"
+
+	"github.com/shogo82148/int128"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlValues struct {
+	U int128.Uint128 `yaml:"u"`
+	I int128.Int128  `yaml:"i"`
+}
+
+func TestYAML(t *testing.T) {
+	testCases := []struct {
+		v    yamlValues
+		yaml string
+	}{
+		{
+			v:    yamlValues{},
+			yaml: "u: 0\ni: 0\n",
+		},
+		{
+			v:    yamlValues{U: int128.Uint128{H: 0, L: 18446744073709551615}, I: int128.Int128{H: -1, L: 9223372036854775808}},
+			yaml: "u: 18446744073709551615\ni: -9223372036854775808\n",
+		},
+		{
+			v:    yamlValues{U: int128.Uint128{H: 1, L: 0}, I: int128.Int128{H: -1, L: 9223372036854775807}},
+			yaml: "u: \"18446744073709551616\"\ni: \"-9223372036854775809\"\n",
+		},
+		{
+			v:    yamlValues{U: int128.Uint128{H: 18446744073709551615, L: 18446744073709551615}, I: int128.Int128{H: 9223372036854775807, L: 18446744073709551615}},
+			yaml: "u: \"340282366920938463463374607431768211455\"\ni: \"170141183460469231731687303715884105727\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		data, err := yaml.Marshal(tc.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tc.yaml {
+			t.Errorf("want %q, got %q", tc.yaml, data)
+		}
+
+		var got yamlValues
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.v {
+			t.Errorf("want %#v, got %#v", tc.v, got)
+		}
+	}
+}
+
+func TestYAML_Unquoted(t *testing.T) {
+	// large integers are decoded without precision loss even if they are not quoted.
+	input := "u: 340282366920938463463374607431768211455\ni: -170141183460469231731687303715884105728\n"
+	var got yamlValues
+	if err := yaml.Unmarshal([]byte(input), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := yamlValues{U: int128.Uint128{H: 18446744073709551615, L: 18446744073709551615}, I: int128.Int128{H: -9223372036854775808, L: 0}}
+	if got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
@@ -2,6 +2,7 @@ package int128_test
 
 import (
 	"fmt"
+	"math/rand"
 
 	"github.com/shogo82148/int128"
 )
@@ -45,3 +46,25 @@ func ExampleUint128_Sub() {
 	fmt.Println(c)
 	// Output: 1
 }
+
+func ExampleSum() {
+	a := int128.Int128{0, 1}         // = 1
+	b := int128.Int128{0, 2}         // = 2
+	c := int128.Int128{0, 4}.Neg()   // = -4
+	fmt.Println(int128.Sum(a, b, c)) // = -1
+	fmt.Println(int128.Max(a, b, c))
+	fmt.Println(int128.Sum(int128.Builtin[int]{1}, int128.Builtin[int]{2}))
+	// Output:
+	// -1
+	// 2
+	// 3
+}
+
+func ExampleRand_Int128Range() {
+	r := int128.NewRand(rand.New(rand.NewSource(1)))
+	min := int128.Int128{-1, 0} // = -2**64
+	max := int128.Int128{1, 0}  // = 2**64
+	v := r.Int128Range(min, max)
+	fmt.Println(v.Cmp(min) >= 0 && v.Cmp(max) < 0)
+	// Output: true
+}
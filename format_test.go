@@ -48,6 +48,10 @@ func TestInt128Format(t *testing.T) {
 		{"%v", Int128{0, 1}.Neg(), "-1"},
 		{"%#v", Int128{0, 0}, "Int128{H: 0x0000000000000000, L: 0x0000000000000000}"},
 		{"%#v", Int128{0, 1}.Neg(), "Int128{H: -0x000000000000001, L: 0xffffffffffffffff}"},
+
+		{"%d", Int128{-1 << 63, 0}, "-170141183460469231731687303715884105728"},
+		{"%+d", Int128{-1 << 63, 0}, "-170141183460469231731687303715884105728"},
+		{"%x", Int128{-1 << 63, 0}, "-80000000000000000000000000000000"},
 	}
 
 	for _, tt := range tests {
@@ -104,3 +108,54 @@ func TestUint128Format(t *testing.T) {
 		}
 	}
 }
+
+func TestUint256Format(t *testing.T) {
+	tests := []struct {
+		format string
+		value  Uint256
+		want   string
+	}{
+		{"%d", Uint256{}, "0"},
+		{"%+d", Uint256{}, "+0"},
+		{"%08d", Uint256{L: Uint128{0, 42}}, "00000042"},
+		{"%-8d", Uint256{L: Uint128{0, 42}}, "42      "},
+		{"%#x", Uint256{H: Uint128{0, 1}}, "0x100000000000000000000000000000000"},
+		{"%X", Uint256{L: Uint128{0, 0xabcd}}, "ABCD"},
+		{"%#o", Uint256{L: Uint128{0, 8}}, "010"},
+		{"%v", Uint256{L: Uint128{0, 42}}, "42"},
+		{"%#v", Uint256{}, "Uint256{H: Uint128{H: 0x0000000000000000, L: 0x0000000000000000}, L: Uint128{H: 0x0000000000000000, L: 0x0000000000000000}}"},
+	}
+
+	for _, tt := range tests {
+		got := fmt.Sprintf(tt.format, tt.value)
+		if got != tt.want {
+			t.Errorf("%#v: want %q, got %q", tt, tt.want, got)
+		}
+	}
+}
+
+func TestInt256Format(t *testing.T) {
+	minusOne := Int256{Int128{-1, 0xffffffffffffffff}, Uint128{0xffffffffffffffff, 0xffffffffffffffff}}
+	tests := []struct {
+		format string
+		value  Int256
+		want   string
+	}{
+		{"%d", Int256{}, "0"},
+		{"%d", minusOne, "-1"},
+		{"%+d", Int256{}, "+0"},
+		{"% d", minusOne, "-1"},
+		{"%08d", minusOne, "-0000001"},
+		{"%8d", minusOne, "      -1"},
+		{"%#x", minusOne.Lsh(128), "-0x100000000000000000000000000000000"},
+		{"%v", minusOne, "-1"},
+		{"%#v", Int256{}, "Int256{H: Int128{H: 0x0000000000000000, L: 0x0000000000000000}, L: Uint128{H: 0x0000000000000000, L: 0x0000000000000000}}"},
+	}
+
+	for _, tt := range tests {
+		got := fmt.Sprintf(tt.format, tt.value)
+		if got != tt.want {
+			t.Errorf("%#v: want %q, got %q", tt, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package int128
+
+import "strconv"
+
+// Integer is the interface implemented by the fixed-width integer types of this package,
+// such as Int128 and Uint128.
+// T is the type itself, e.g. Int128 implements Integer[Int128].
+//
+// The builtin integer types can be used through the Builtin adapter.
+type Integer[T any] interface {
+	Add(b T) T
+	Sub(b T) T
+	Mul(b T) T
+	Div(b T) T
+	Mod(b T) T
+	And(b T) T
+	Or(b T) T
+	Xor(b T) T
+	Lsh(i uint) T
+	Rsh(i uint) T
+	Cmp(b T) int
+	Text(base int) string
+	Append(dst []byte, base int) []byte
+}
+
+// Signed is the interface implemented by the signed integer types of this package,
+// such as Int128.
+type Signed[T any] interface {
+	Integer[T]
+	Neg() T
+	Sign() int
+}
+
+var (
+	_ Integer[Uint128]     = Uint128{}
+	_ Integer[Uint256]     = Uint256{}
+	_ Signed[Int128]       = Int128{}
+	_ Signed[Int256]       = Int256{}
+	_ Signed[Builtin[int]] = Builtin[int]{}
+)
+
+// BuiltinInteger is a constraint that permits any builtin integer type.
+type BuiltinInteger interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Builtin adapts the builtin integer type T to the Integer and Signed interfaces.
+// The arithmetic wraps around as the builtin operators do,
+// and Div and Mod implement Euclidean division like Int128.
+type Builtin[T BuiltinInteger] struct {
+	V T
+}
+
+// isSigned reports whether T is a signed integer type.
+func isSigned[T BuiltinInteger]() bool {
+	var zero T
+	return ^zero < zero
+}
+
+// Add returns the sum a+b.
+func (a Builtin[T]) Add(b Builtin[T]) Builtin[T] {
+	return Builtin[T]{a.V + b.V}
+}
+
+// Sub returns the difference a-b.
+func (a Builtin[T]) Sub(b Builtin[T]) Builtin[T] {
+	return Builtin[T]{a.V - b.V}
+}
+
+// Mul returns the product a*b.
+func (a Builtin[T]) Mul(b Builtin[T]) Builtin[T] {
+	return Builtin[T]{a.V * b.V}
+}
+
+// Div returns the quotient a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// Div implements Euclidean division (unlike Go); see DivMod for more details.
+func (a Builtin[T]) Div(b Builtin[T]) Builtin[T] {
+	q, _ := a.DivMod(b)
+	return q
+}
+
+// Mod returns the modulus a%b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// Mod implements Euclidean modulus (unlike Go); see DivMod for more details.
+func (a Builtin[T]) Mod(b Builtin[T]) Builtin[T] {
+	_, m := a.DivMod(b)
+	return m
+}
+
+// DivMod returns the quotient and remainder of a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+//
+// DivMod implements Euclidean division and modulus (unlike Go):
+//
+//	q = a div b  such that
+//	m = a - b*q  with 0 <= m < |y|
+func (a Builtin[T]) DivMod(b Builtin[T]) (Builtin[T], Builtin[T]) {
+	var zero T
+	q, m := a.V/b.V, a.V%b.V
+	if m < zero {
+		if b.V < zero {
+			q++
+			m -= b.V
+		} else {
+			q--
+			m += b.V
+		}
+	}
+	return Builtin[T]{q}, Builtin[T]{m}
+}
+
+// And returns the bitwise AND a&b.
+func (a Builtin[T]) And(b Builtin[T]) Builtin[T] {
+	return Builtin[T]{a.V & b.V}
+}
+
+// Or returns the bitwise OR a|b.
+func (a Builtin[T]) Or(b Builtin[T]) Builtin[T] {
+	return Builtin[T]{a.V | b.V}
+}
+
+// Xor returns the bitwise XOR a^b.
+func (a Builtin[T]) Xor(b Builtin[T]) Builtin[T] {
+	return Builtin[T]{a.V ^ b.V}
+}
+
+// Lsh returns the left shift a<<i.
+func (a Builtin[T]) Lsh(i uint) Builtin[T] {
+	return Builtin[T]{a.V << i}
+}
+
+// Rsh returns the right shift a>>i.
+// The shift is arithmetic if T is signed, and logical otherwise.
+func (a Builtin[T]) Rsh(i uint) Builtin[T] {
+	return Builtin[T]{a.V >> i}
+}
+
+// Cmp compares a and b and returns:
+//
+//	-1 if a <  b
+//	 0 if a == b
+//	+1 if a >  b
+func (a Builtin[T]) Cmp(b Builtin[T]) int {
+	if a.V < b.V {
+		return -1
+	}
+	if a.V > b.V {
+		return 1
+	}
+	return 0
+}
+
+// Neg returns the negation -a.
+func (a Builtin[T]) Neg() Builtin[T] {
+	return Builtin[T]{-a.V}
+}
+
+// Sign returns:
+//
+//	-1 if a <  0
+//	 0 if a == 0
+//	+1 if a >  0
+func (a Builtin[T]) Sign() int {
+	var zero T
+	return a.Cmp(Builtin[T]{zero})
+}
+
+// Text returns the string representation of a in the given base.
+// Base must be between 2 and 36, inclusive.
+func (a Builtin[T]) Text(base int) string {
+	if isSigned[T]() {
+		return strconv.FormatInt(int64(a.V), base)
+	}
+	return strconv.FormatUint(uint64(a.V), base)
+}
+
+// Append appends the string representation of a, as generated by a.Text(base), to buf and returns the extended buffer.
+func (a Builtin[T]) Append(dst []byte, base int) []byte {
+	if isSigned[T]() {
+		return strconv.AppendInt(dst, int64(a.V), base)
+	}
+	return strconv.AppendUint(dst, uint64(a.V), base)
+}
+
+// String returns the decimal representation of a as generated by a.Text(10).
+func (a Builtin[T]) String() string {
+	return a.Text(10)
+}
+
+// Sum returns the sum of values.
+// The sum wraps around on overflow, and Sum returns zero if values is empty.
+func Sum[T Integer[T]](values ...T) T {
+	var sum T
+	for _, v := range values {
+		sum = sum.Add(v)
+	}
+	return sum
+}
+
+// Min returns the smallest value of x and the rest of the values.
+func Min[T Integer[T]](x T, rest ...T) T {
+	for _, v := range rest {
+		if v.Cmp(x) < 0 {
+			x = v
+		}
+	}
+	return x
+}
+
+// Max returns the largest value of x and the rest of the values.
+func Max[T Integer[T]](x T, rest ...T) T {
+	for _, v := range rest {
+		if v.Cmp(x) > 0 {
+			x = v
+		}
+	}
+	return x
+}
+
+// Abs returns the absolute value |x|.
+// Like the builtin integer types, the absolute value of the minimum value wraps around to itself.
+func Abs[T Signed[T]](x T) T {
+	if x.Sign() < 0 {
+		return x.Neg()
+	}
+	return x
+}
+
+// Accumulate folds values into init from left to right using op, and returns the result.
+// If op is nil, Accumulate adds the values up.
+func Accumulate[T Integer[T]](values []T, init T, op func(acc, v T) T) T {
+	if op == nil {
+		op = func(acc, v T) T { return acc.Add(v) }
+	}
+	for _, v := range values {
+		init = op(init, v)
+	}
+	return init
+}
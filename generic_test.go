@@ -0,0 +1,131 @@
+package int128
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestSum(t *testing.T) {
+	if got, want := Sum[Int128](), (Int128{}); got != want {
+		t.Errorf("Sum() should %#v, but %#v", want, got)
+	}
+	if got, want := Sum(Int128{0, 1}, Int128{0, 2}, Int128{0, 3}.Neg()), (Int128{}); got != want {
+		t.Errorf("Sum(1, 2, -3) should %#v, but %#v", want, got)
+	}
+	if got, want := Sum(Uint128{0, math.MaxUint64}, Uint128{0, 1}), (Uint128{1, 0}); got != want {
+		t.Errorf("Sum(2**64-1, 1) should %#v, but %#v", want, got)
+	}
+	if got, want := Sum(Builtin[int8]{100}, Builtin[int8]{100}), (Builtin[int8]{-56}); got != want {
+		t.Errorf("Sum(100, 100) should %#v, but %#v", want, got)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	values := []Int128{{0, 3}, {-1, math.MaxUint64 - 4}, {0, 5}, {-1, math.MaxUint64}}
+	if got, want := Min(values[0], values[1:]...), (Int128{-1, math.MaxUint64 - 4}); got != want {
+		t.Errorf("Min should %#v, but %#v", want, got)
+	}
+	if got, want := Max(values[0], values[1:]...), (Int128{0, 5}); got != want {
+		t.Errorf("Max should %#v, but %#v", want, got)
+	}
+	if got, want := Min(Uint128{0, 42}), (Uint128{0, 42}); got != want {
+		t.Errorf("Min should %#v, but %#v", want, got)
+	}
+	if got, want := Max(Builtin[uint]{1}, Builtin[uint]{math.MaxUint}), (Builtin[uint]{math.MaxUint}); got != want {
+		t.Errorf("Max should %#v, but %#v", want, got)
+	}
+}
+
+func TestAbs(t *testing.T) {
+	if got, want := Abs(Int128{0, 1}.Neg()), (Int128{0, 1}); got != want {
+		t.Errorf("Abs(-1) should %#v, but %#v", want, got)
+	}
+	if got, want := Abs(Int256{}.Sub(Int256{L: Uint128{0, 2}})), (Int256{L: Uint128{0, 2}}); got != want {
+		t.Errorf("Abs(-2) should %#v, but %#v", want, got)
+	}
+	if got, want := Abs(Builtin[int]{-3}), (Builtin[int]{3}); got != want {
+		t.Errorf("Abs(-3) should %#v, but %#v", want, got)
+	}
+	if got, want := Abs(Builtin[int8]{math.MinInt8}), (Builtin[int8]{math.MinInt8}); got != want {
+		t.Errorf("Abs(MinInt8) should %#v, but %#v", want, got)
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	values := []Uint128{{0, 1}, {0, 2}, {0, 3}, {0, 4}}
+	if got, want := Accumulate(values, Uint128{}, nil), (Uint128{0, 10}); got != want {
+		t.Errorf("Accumulate(+) should %#v, but %#v", want, got)
+	}
+	if got, want := Accumulate(values, Uint128{0, 1}, Uint128.Mul), (Uint128{0, 24}); got != want {
+		t.Errorf("Accumulate(*) should %#v, but %#v", want, got)
+	}
+	if got, want := Accumulate(nil, Int128{0, 7}, Int128.Sub), (Int128{0, 7}); got != want {
+		t.Errorf("Accumulate(empty) should %#v, but %#v", want, got)
+	}
+}
+
+func TestBuiltin_DivModQuick(t *testing.T) {
+	f := func(a, b int64) [2]int64 {
+		if b == 0 || (a == math.MinInt64 && b == -1) {
+			// the quotient doesn't fit in int64.
+			return [2]int64{}
+		}
+		q, m := Builtin[int64]{a}.DivMod(Builtin[int64]{b})
+		return [2]int64{q.V, m.V}
+	}
+	g := func(a, b int64) [2]int64 {
+		if b == 0 || (a == math.MinInt64 && b == -1) {
+			return [2]int64{}
+		}
+		q, m := new(big.Int).DivMod(big.NewInt(a), big.NewInt(b), new(big.Int))
+		return [2]int64{q.Int64(), m.Int64()}
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+
+	// small values often divide evenly.
+	f2 := func(a, b int8) [2]int64 { return f(int64(a), int64(b)) }
+	g2 := func(a, b int8) [2]int64 { return g(int64(a), int64(b)) }
+	if err := quick.CheckEqual(f2, g2, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBuiltin_Text(t *testing.T) {
+	testCases := []struct {
+		got, want string
+	}{
+		{Builtin[int8]{-128}.Text(10), "-128"},
+		{Builtin[uint8]{255}.Text(16), "ff"},
+		{Builtin[uint64]{math.MaxUint64}.String(), "18446744073709551615"},
+		{string(Builtin[int]{-42}.Append([]byte("x="), 10)), "x=-42"},
+	}
+	for i, tc := range testCases {
+		if tc.got != tc.want {
+			t.Errorf("%d: want %q, got %q", i, tc.want, tc.got)
+		}
+	}
+}
+
+// sumOfSquares is an example of the generic code over Integer.
+func sumOfSquares[T Integer[T]](values ...T) T {
+	return Accumulate(values, Sum[T](), func(acc, v T) T {
+		return acc.Add(v.Mul(v))
+	})
+}
+
+func TestGenericQuick(t *testing.T) {
+	f := func(a, b, c int16) Int128 {
+		x := sumOfSquares(Builtin[int64]{int64(a)}, Builtin[int64]{int64(b)}, Builtin[int64]{int64(c)})
+		return Int128{x.V >> 63, uint64(x.V)}
+	}
+	g := func(a, b, c int16) Int128 {
+		return sumOfSquares(Int128{int64(a) >> 63, uint64(int64(a))}, Int128{int64(b) >> 63, uint64(int64(b))}, Int128{int64(c) >> 63, uint64(int64(c))})
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
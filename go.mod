@@ -1,3 +1,3 @@
 module github.com/shogo82148/int128
 
-go 1.17
+go 1.18
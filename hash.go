@@ -0,0 +1,27 @@
+package int128
+
+import "math/bits"
+
+// the constants of wyhash.
+const (
+	hashKey0 = 0xa0761d6478bd642f
+	hashKey1 = 0xe7037ed1a0b428db
+	hashKey2 = 0x8ebc6af09c88c6e3
+)
+
+// hashMix multiplies a and b, and folds the 128-bit product into 64 bits.
+// The operands are xored back into the result like the mum function of wyhash,
+// so that the result doesn't collapse to zero when one of the operands is zero.
+func hashMix(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return (a ^ lo) ^ (b ^ hi)
+}
+
+// Hash returns a 64-bit hash of a mixed with seed.
+// It is fast and has good avalanche properties, so it is suitable for
+// the hash of custom hash tables; it is not a cryptographic hash.
+// The result depends only on a and seed, so pick a random seed for each table
+// to protect it from hash flooding.
+func (a Uint128) Hash(seed uint64) uint64 {
+	return hashMix(hashMix(a.L^seed^hashKey0, a.H^seed^hashKey1), hashKey2^seed)
+}
@@ -0,0 +1,73 @@
+package hash128
+
+import "github.com/shogo82148/int128"
+
+// the parameters of 128-bit FNV.
+// See http://www.isthe.com/chongo/tech/comp/fnv/
+var (
+	fnvOffset128 = int128.Uint128{H: 0x6c62272e07bb0142, L: 0x62b821756295c58d}
+	fnvPrime128  = int128.Uint128{H: 0x0000000001000000, L: 0x000000000000013b}
+)
+
+type (
+	fnv128  int128.Uint128
+	fnv128a int128.Uint128
+)
+
+// NewFNV128 returns a new 128-bit FNV-1 hash.
+// It produces the same digest as [hash/fnv.New128].
+func NewFNV128() Hash128 {
+	s := fnv128(fnvOffset128)
+	return &s
+}
+
+// NewFNV128a returns a new 128-bit FNV-1a hash.
+// It produces the same digest as [hash/fnv.New128a].
+func NewFNV128a() Hash128 {
+	s := fnv128a(fnvOffset128)
+	return &s
+}
+
+func (s *fnv128) Reset()         { *s = fnv128(fnvOffset128) }
+func (s *fnv128) Size() int      { return 16 }
+func (s *fnv128) BlockSize() int { return 1 }
+
+func (s *fnv128) Write(data []byte) (int, error) {
+	h := int128.Uint128(*s)
+	for _, c := range data {
+		h = h.Mul(fnvPrime128)
+		h.L ^= uint64(c)
+	}
+	*s = fnv128(h)
+	return len(data), nil
+}
+
+func (s *fnv128) Sum(in []byte) []byte {
+	return appendUint128(in, int128.Uint128(*s))
+}
+
+func (s *fnv128) Sum128() int128.Uint128 {
+	return int128.Uint128(*s)
+}
+
+func (s *fnv128a) Reset()         { *s = fnv128a(fnvOffset128) }
+func (s *fnv128a) Size() int      { return 16 }
+func (s *fnv128a) BlockSize() int { return 1 }
+
+func (s *fnv128a) Write(data []byte) (int, error) {
+	h := int128.Uint128(*s)
+	for _, c := range data {
+		h.L ^= uint64(c)
+		h = h.Mul(fnvPrime128)
+	}
+	*s = fnv128a(h)
+	return len(data), nil
+}
+
+func (s *fnv128a) Sum(in []byte) []byte {
+	return appendUint128(in, int128.Uint128(*s))
+}
+
+func (s *fnv128a) Sum128() int128.Uint128 {
+	return int128.Uint128(*s)
+}
@@ -0,0 +1,101 @@
+package hash128
+
+import (
+	"bytes"
+	"crypto/md5"
+	"hash/fnv"
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+)
+
+func TestFNV128(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want int128.Uint128
+	}{
+		{"", fnvOffset128},
+		{"a", int128.Uint128{H: 0xd228cb696f1a8caf, L: 0x78912b704e4a8964}},
+	}
+	for i, tc := range testCases {
+		h := NewFNV128a()
+		h.Write([]byte(tc.in))
+		if got := h.Sum128(); got != tc.want {
+			t.Errorf("%d: FNV-1a(%q) should %#v, but %#v", i, tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestFNV128Quick(t *testing.T) {
+	f := func(data []byte) []byte {
+		h := NewFNV128()
+		h.Write(data)
+		return h.Sum([]byte("prefix"))
+	}
+	g := func(data []byte) []byte {
+		h := fnv.New128()
+		h.Write(data)
+		return h.Sum([]byte("prefix"))
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFNV128aQuick(t *testing.T) {
+	f := func(a, b []byte) []byte {
+		// Write can be called many times.
+		h := NewFNV128a()
+		h.Write(a)
+		h.Write(b)
+		return h.Sum(nil)
+	}
+	g := func(a, b []byte) []byte {
+		h := fnv.New128a()
+		h.Write(a)
+		h.Write(b)
+		return h.Sum(nil)
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFNV128_Reset(t *testing.T) {
+	for _, h := range []Hash128{NewFNV128(), NewFNV128a()} {
+		h.Write([]byte("hello"))
+		h.Reset()
+		if got := h.Sum128(); got != fnvOffset128 {
+			t.Errorf("%T: Sum128() after Reset() should %#v, but %#v", h, fnvOffset128, got)
+		}
+		if h.Size() != 16 || h.BlockSize() != 1 {
+			t.Errorf("%T: unexpected size: %d, %d", h, h.Size(), h.BlockSize())
+		}
+	}
+}
+
+func TestSum128Of(t *testing.T) {
+	h := md5.New()
+	h.Write([]byte("hello"))
+	sum := h.Sum(nil)
+	got := Sum128Of(h)
+	if !bytes.Equal(appendUint128(nil, got), sum) {
+		t.Errorf("Sum128Of() should %x, but %#v", sum, got)
+	}
+
+	h1 := NewFNV128a()
+	h1.Write([]byte("hello"))
+	if got, want := Sum128Of(h1), h1.Sum128(); got != want {
+		t.Errorf("Sum128Of() should %#v, but %#v", want, got)
+	}
+}
+
+func BenchmarkFNV128a(b *testing.B) {
+	data := make([]byte, 1024)
+	h := NewFNV128a()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		h.Write(data)
+	}
+}
@@ -0,0 +1,48 @@
+// Package hash128 provides 128-bit hash functions whose output is an int128.Uint128.
+package hash128
+
+import (
+	"hash"
+
+	"github.com/shogo82148/int128"
+)
+
+// Hash128 is the common interface implemented by all 128-bit hash functions.
+type Hash128 interface {
+	hash.Hash
+	Sum128() int128.Uint128
+}
+
+// Sum128Of returns the current hash of h as an int128.Uint128.
+// It can be used with any 128-bit hash.Hash, such as xxh3-128 or murmur3-128 implementations.
+// The digest returned by h.Sum is interpreted as a big-endian integer.
+// It panics if h.Size() is not 16.
+func Sum128Of(h hash.Hash) int128.Uint128 {
+	if h, ok := h.(Hash128); ok {
+		return h.Sum128()
+	}
+	if h.Size() != 16 {
+		panic("hash128: the hash size must be 16 bytes")
+	}
+	var buf [16]byte
+	return fromBytes(h.Sum(buf[:0]))
+}
+
+func fromBytes(b []byte) int128.Uint128 {
+	var v int128.Uint128
+	for i := 0; i < 8; i++ {
+		v.H = v.H<<8 | uint64(b[i])
+		v.L = v.L<<8 | uint64(b[i+8])
+	}
+	return v
+}
+
+func appendUint128(b []byte, v int128.Uint128) []byte {
+	for i := 56; i >= 0; i -= 8 {
+		b = append(b, byte(v.H>>i))
+	}
+	for i := 56; i >= 0; i -= 8 {
+		b = append(b, byte(v.L>>i))
+	}
+	return b
+}
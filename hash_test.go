@@ -0,0 +1,88 @@
+package int128
+
+import (
+	"math/bits"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+func TestUint128_Hash(t *testing.T) {
+	// the hash must be deterministic.
+	f := func(a Uint128, seed uint64) bool {
+		return a.Hash(seed) == a.Hash(seed)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	// small keys must not collide.
+	seen := make(map[uint64]Uint128)
+	for h := uint64(0); h < 16; h++ {
+		for l := uint64(0); l < 4096; l++ {
+			a := Uint128{h, l}
+			v := a.Hash(0)
+			if b, ok := seen[v]; ok {
+				t.Fatalf("%#v and %#v collide", a, b)
+			}
+			seen[v] = a
+		}
+	}
+}
+
+func TestUint128_HashZeroOperand(t *testing.T) {
+	// the first multiplication has the zero operand if a.L == seed^hashKey0.
+	// the hash must still depend on a.H.
+	f := func(seed uint64) bool {
+		seen := make(map[uint64]uint64)
+		for h := uint64(0); h < 256; h++ {
+			a := Uint128{h, seed ^ hashKey0}
+			v := a.Hash(seed)
+			if g, ok := seen[v]; ok {
+				t.Errorf("seed %#x: H = %d and H = %d collide", seed, h, g)
+				return false
+			}
+			seen[v] = h
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128_HashAvalanche(t *testing.T) {
+	// flipping one bit of the input flips about half of the output bits.
+	const n = 1000
+	var total int
+	f := func(a Uint128, seed uint64, i uint8) bool {
+		b := a.Xor(Uint128{0, 1}.Lsh(uint(i % 128)))
+		total += bits.OnesCount64(a.Hash(seed) ^ b.Hash(seed))
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: n}); err != nil {
+		t.Fatal(err)
+	}
+	if avg := float64(total) / n; avg < 30 || avg > 34 {
+		t.Errorf("the average of the flipped bits should be about 32, but %f", avg)
+	}
+
+	// so does flipping one bit of the seed.
+	total = 0
+	g := func(a Uint128, seed uint64, i uint8) bool {
+		total += bits.OnesCount64(a.Hash(seed) ^ a.Hash(seed^(1<<(i%64))))
+		return true
+	}
+	if err := quick.Check(g, &quick.Config{MaxCount: n}); err != nil {
+		t.Fatal(err)
+	}
+	if avg := float64(total) / n; avg < 30 || avg > 34 {
+		t.Errorf("the average of the flipped bits should be about 32, but %f", avg)
+	}
+}
+
+func BenchmarkUint128_Hash(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint128Input.Hash(uint64(i)))
+	}
+}
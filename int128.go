@@ -55,46 +55,15 @@ func (a Int128) Mul(b Int128) Int128 {
 // Div returns the quotient a/b for b != 0.
 // If b == 0, a division-by-zero run-time panic occurs.
 func (a Int128) Div(b Int128) Int128 {
-	var negA, negB bool
-	if a.H < 0 {
-		negA = true
-		a = a.Neg()
-	}
-	if b.H < 0 {
-		negB = true
-		b = b.Neg()
-	}
-
-	div := a.Uint128().Div(b.Uint128())
-	if negA {
-		div = div.Add(Uint128{0, 1})
-	}
-	if negA != negB {
-		div = div.Neg()
-	}
-
-	return div.Int128()
-
+	div, _ := a.DivMod(b)
+	return div
 }
 
 // Mod returns the modulus x%y for y != 0.
 // If y == 0, a division-by-zero run-time panic occurs.
 func (a Int128) Mod(b Int128) Int128 {
-	var negA bool
-	if a.H < 0 {
-		negA = true
-		a = a.Neg()
-	}
-	if b.H < 0 {
-		b = b.Neg()
-	}
-
-	mod := a.Uint128().Mod(b.Uint128())
-	if negA {
-		mod = mod.Neg().Add(b.Uint128())
-	}
-
-	return mod.Int128()
+	_, mod := a.DivMod(b)
+	return mod
 }
 
 // DivMod returns the quotient and remainder of a/b for b != 0.
@@ -103,7 +72,10 @@ func (a Int128) Mod(b Int128) Int128 {
 // DivMod implements Euclidean division and modulus (unlike Go):
 //
 //	q = a div b  such that
-//	m = a - b*q  with 0 <= m < |y|
+//	m = a - b*q  with 0 <= m < |b|
+//
+// Div, Mod and DivMod in v0.2.0 and earlier returned wrong results when a is negative and divisible by b,
+// e.g. -4 div 2 was -3 and -4 mod 2 was 2. See CHANGELOG.md.
 func (a Int128) DivMod(b Int128) (Int128, Int128) {
 	var negA, negB bool
 	if a.H < 0 {
@@ -116,17 +88,14 @@ func (a Int128) DivMod(b Int128) (Int128, Int128) {
 	}
 
 	div, mod := a.Uint128().DivMod(b.Uint128())
+	if negA && (mod.H != 0 || mod.L != 0) {
+		// round the quotient away from zero so that the modulus is positive.
+		mod = b.Uint128().Sub(mod)
+		div = div.Add(Uint128{0, 1})
+	}
 	if negA != negB {
 		div = div.Neg()
 	}
-	if negA {
-		mod = mod.Neg().Add(b.Uint128())
-		if negB {
-			div = div.Add(Uint128{0, 1})
-		} else {
-			div = div.Sub(Uint128{0, 1})
-		}
-	}
 
 	return div.Int128(), mod.Int128()
 }
@@ -201,6 +170,41 @@ func (a Int128) QuoRem(b Int128) (Int128, Int128) {
 	return div.Int128(), mod.Int128()
 }
 
+// DivFloor returns the quotient a/b rounded toward negative infinity for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+func (a Int128) DivFloor(b Int128) Int128 {
+	return a.DivRound(b, ToNegativeInf)
+}
+
+// DivCeil returns the quotient a/b rounded toward positive infinity for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+func (a Int128) DivCeil(b Int128) Int128 {
+	return a.DivRound(b, ToPositiveInf)
+}
+
+// DivRound returns the quotient a/b rounded according to mode for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+func (a Int128) DivRound(b Int128, mode RoundingMode) Int128 {
+	var negA, negB bool
+	if a.H < 0 {
+		negA = true
+		a = a.Neg()
+	}
+	if b.H < 0 {
+		negB = true
+		b = b.Neg()
+	}
+
+	div, mod := a.Uint128().DivMod(b.Uint128())
+	if mode.roundUp(div, mod, b.Uint128(), negA != negB) {
+		div = div.Add(Uint128{0, 1})
+	}
+	if negA != negB {
+		div = div.Neg()
+	}
+	return div.Int128()
+}
+
 // Cmp compares a and b and returns:
 //
 //	-1 if a <  b
@@ -295,6 +299,68 @@ func (a Int128) Uint128() Uint128 {
 	return Uint128{uint64(a.H), a.L}
 }
 
+// Int128FromSignMagnitude returns the Int128 value of the sign neg and the magnitude mag,
+// and reports whether the value fits in Int128.
+// If it doesn't fit, the returned value wraps around.
+func Int128FromSignMagnitude(neg bool, mag Uint128) (Int128, bool) {
+	if neg {
+		return mag.Neg().Int128(), mag.H < 1<<63 || (mag.H == 1<<63 && mag.L == 0)
+	}
+	return mag.Int128(), mag.H < 1<<63
+}
+
+// Abs returns the absolute value |a|.
+// The absolute value of the minimum value of Int128 cannot be represented,
+// so Abs returns a unchanged in that case. Use AbsUint128 to get the correct magnitude.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int128) Abs() Int128 {
+	// mask is -1 if a is negative, otherwise 0.
+	mask := a.H >> 63
+	l, borrow := bits.Sub64(a.L^uint64(mask), uint64(mask), 0)
+	h, _ := bits.Sub64(uint64(a.H^mask), uint64(mask), borrow)
+	return Int128{int64(h), l}
+}
+
+// AbsUint128 returns the absolute value |a| as an unsigned 128-bit integer.
+// Unlike Abs, the result is correct for the minimum value of Int128.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int128) AbsUint128() Uint128 {
+	return a.Abs().Uint128()
+}
+
+// Sign returns:
+//
+//	-1 if a <  0
+//	 0 if a == 0
+//	+1 if a >  0
+func (a Int128) Sign() int {
+	if a.H < 0 {
+		return -1
+	}
+	if a.H == 0 && a.L == 0 {
+		return 0
+	}
+	return 1
+}
+
+// IsNeg reports whether a is negative.
+func (a Int128) IsNeg() bool {
+	return a.H < 0
+}
+
+// CopySign returns a value with the magnitude of a and the sign of sign.
+// If sign is zero, the result is not negative.
+// The result wraps around if a is the minimum value of Int128 and sign is not negative.
+func (a Int128) CopySign(sign Int128) Int128 {
+	a = a.Abs()
+	if sign.H < 0 {
+		a = a.Neg()
+	}
+	return a
+}
+
 // Float64ToUint128 returns the nearest Uint128 representation of v.
 func Float64ToInt128(v float64) Int128 {
 	b := math.Float64bits(v)
@@ -328,16 +394,16 @@ func (a Int128) Text(base int) string {
 	if base == 10 && a.H == 0 && a.L < nSmalls {
 		return small(int(a.L))
 	}
-	_, s := formatUint128(nil, uint64(a.H), a.L, base, a.H < 0, false)
+	_, s := formatUint128(nil, uint64(a.H), a.L, base, a.IsNeg(), false)
 	return s
 }
 
 // Append appends the string representation of a, as generated by a.Text(base), to buf and returns the extended buffer.
 func (a Int128) Append(dst []byte, base int) []byte {
-	if base == 10 && a.H == 0 && a.L < nSmalls {
-		return append(dst, small(int(a.L))...)
+	if base == 10 {
+		return a.AppendDecimal(dst)
 	}
-	d, _ := formatUint128(dst, uint64(a.H), a.L, base, a.H < 0, true)
+	d, _ := formatUint128(dst, uint64(a.H), a.L, base, a.IsNeg(), true)
 	return d
 }
 
@@ -346,6 +412,6 @@ func (a Int128) String() string {
 	if a.H == 0 && a.L < nSmalls {
 		return small(int(a.L))
 	}
-	_, s := formatUint128(nil, uint64(a.H), a.L, 10, a.H < 0, false)
-	return s
+	var buf [maxDecimalLen + 1]byte // +1 is for the sign
+	return string(a.AppendDecimal(buf[:0]))
 }
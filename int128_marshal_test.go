@@ -3,7 +3,11 @@ package int128
 import (
 	"encoding"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"math"
 	"testing"
+	"testing/quick"
 )
 
 var _ = json.Marshaler(Int128{})
@@ -19,3 +23,200 @@ func TestInt128_MarshalJSON(t *testing.T) {
 		t.Errorf("want %q, got %q", "12345", string(data))
 	}
 }
+
+var _ = json.Unmarshaler(&Int128{})
+var _ = encoding.TextUnmarshaler(&Int128{})
+var _ = xml.Marshaler(Int128{})
+var _ = xml.Unmarshaler(&Int128{})
+var _ = xml.MarshalerAttr(Int128{})
+var _ = xml.UnmarshalerAttr(&Int128{})
+var _ = yamlMarshaler(Int128{})
+var _ = yamlUnmarshaler(&Int128{})
+var _ = tomlMarshaler(Int128{})
+var _ = tomlUnmarshaler(&Int128{})
+
+func TestInt128_UnmarshalText(t *testing.T) {
+	testCases := []struct {
+		text string
+		want Int128
+		err  error
+	}{
+		{"0", Int128{0, 0}, nil},
+		{"-0", Int128{0, 0}, nil},
+		{"+0", Int128{0, 0}, nil},
+		{"12345", Int128{0, 12345}, nil},
+		{"+12345", Int128{0, 12345}, nil},
+		{"-12345", Int128{-1, -12345 & math.MaxUint64}, nil},
+		{"-0012345", Int128{-1, -12345 & math.MaxUint64}, nil},
+		{"-18446744073709551616", Int128{-1, 0}, nil},
+		{"170141183460469231731687303715884105727", Int128{math.MaxInt64, math.MaxUint64}, nil},
+		{"-170141183460469231731687303715884105728", Int128{math.MinInt64, 0}, nil},
+		{"170141183460469231731687303715884105728", Int128{}, ErrRange},
+		{"-170141183460469231731687303715884105729", Int128{}, ErrRange},
+		{"-340282366920938463463374607431768211456", Int128{}, ErrRange},
+		{"", Int128{}, ErrSyntax},
+		{"-", Int128{}, ErrSyntax},
+		{"+-1", Int128{}, ErrSyntax},
+		{"--1", Int128{}, ErrSyntax},
+		{"1-", Int128{}, ErrSyntax},
+		{"1e3", Int128{}, ErrSyntax},
+	}
+
+	for i, tc := range testCases {
+		var a Int128
+		err := a.UnmarshalText([]byte(tc.text))
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%d: %q should be error %v, but %v", i, tc.text, tc.err, err)
+			continue
+		}
+		if a != tc.want {
+			t.Errorf("%d: %q should %#v, but %#v", i, tc.text, tc.want, a)
+		}
+	}
+}
+
+func TestInt128_UnmarshalTextQuick(t *testing.T) {
+	f := func(a Int128, shift uint8) bool {
+		a = a.Rsh(uint(shift % 128))
+		var b Int128
+		if err := b.UnmarshalText([]byte(a.String())); err != nil {
+			t.Error(err)
+			return false
+		}
+		return a == b
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		json string
+		want Int128
+	}{
+		{`0`, Int128{0, 0}},
+		{`-12345`, Int128{-1, -12345 & math.MaxUint64}},
+		{`"-12345"`, Int128{-1, -12345 & math.MaxUint64}},
+		{`-170141183460469231731687303715884105728`, Int128{math.MinInt64, 0}},
+		{`"170141183460469231731687303715884105727"`, Int128{math.MaxInt64, math.MaxUint64}},
+	}
+	for i, tc := range testCases {
+		var a Int128
+		if err := json.Unmarshal([]byte(tc.json), &a); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if a != tc.want {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.json, tc.want, a)
+		}
+	}
+
+	// round trip
+	type Account struct {
+		Balance Int128 `json:"balance"`
+	}
+	v := Account{Balance: Int128{math.MinInt64, 0}}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Account
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != v {
+		t.Errorf("want %#v, got %#v", v, got)
+	}
+
+	for _, s := range []string{`1.5`, `"1 "`, `""`, `false`, `[]`, `170141183460469231731687303715884105728`} {
+		var a Int128
+		if err := json.Unmarshal([]byte(s), &a); err == nil {
+			t.Errorf("%s: want error, got nil", s)
+		}
+	}
+}
+
+func TestInt128_XML(t *testing.T) {
+	type Account struct {
+		XMLName xml.Name `xml:"account"`
+		Limit   Int128   `xml:"limit,attr"`
+		Balance Int128   `xml:"balance"`
+	}
+	v := Account{
+		Limit:   Int128{math.MinInt64, 0},
+		Balance: Int128{-1, 0},
+	}
+	data, err := xml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<account limit="-170141183460469231731687303715884105728"><balance>-18446744073709551616</balance></account>`
+	if string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+
+	var got Account
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Limit != v.Limit || got.Balance != v.Balance {
+		t.Errorf("want %#v, got %#v", v, got)
+	}
+}
+
+func TestInt128_YAML(t *testing.T) {
+	testCases := []Int128{
+		{0, 0},
+		{-1, math.MaxUint64},
+		{-1, 1 << 63},
+		{0, math.MaxUint64},
+		{math.MaxInt64, math.MaxUint64},
+		{math.MinInt64, 0},
+	}
+	for i, tc := range testCases {
+		var got Int128
+		if err := yamlRoundTrip(t, tc, &got); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if got != tc {
+			t.Errorf("%d: want %#v, got %#v", i, tc, got)
+		}
+	}
+}
+
+func TestInt128_TOML(t *testing.T) {
+	testCases := []struct {
+		a    Int128
+		toml string
+	}{
+		{Int128{0, 0}, `0`},
+		{Int128{-1, 1 << 63}, `-9223372036854775808`},
+		{Int128{0, math.MaxInt64}, `9223372036854775807`},
+		{Int128{0, 1 << 63}, `"9223372036854775808"`},
+		{Int128{-1, 1<<63 - 1}, `"-9223372036854775809"`},
+		{Int128{math.MinInt64, 0}, `"-170141183460469231731687303715884105728"`},
+	}
+	for i, tc := range testCases {
+		var got Int128
+		s, err := tomlRoundTrip(t, tc.a, &got)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if s != tc.toml {
+			t.Errorf("%d: %#v should be encoded into %s, but %s", i, tc.a, tc.toml, s)
+		}
+		if got != tc.a {
+			t.Errorf("%d: want %#v, got %#v", i, tc.a, got)
+		}
+	}
+
+	var a Int128
+	if err := a.UnmarshalTOML(true); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -342,6 +342,58 @@ func TestInt128_DivModQuick(t *testing.T) {
 	}
 }
 
+func TestInt128_DivModExact(t *testing.T) {
+	// the quick check rarely generates the dividends that are divisible by the divisor.
+	f := func(a, b Int128) [4]Int128 {
+		if b.H == 0 && b.L == 0 {
+			return [4]Int128{}
+		}
+		a = a.Quo(b).Mul(b)
+		div, mod := a.DivMod(b)
+		return [4]Int128{div, mod, a.Div(b), a.Mod(b)}
+	}
+	g := func(a, b Int128) [4]Int128 {
+		if b.H == 0 && b.L == 0 {
+			return [4]Int128{}
+		}
+		a = a.Quo(b).Mul(b)
+		bigA := int128ToBig(new(big.Int), a)
+		bigB := int128ToBig(new(big.Int), b)
+		div, mod := new(big.Int).DivMod(bigA, bigB, new(big.Int))
+		return [4]Int128{bigToInt128(div), bigToInt128(mod), bigToInt128(div), bigToInt128(mod)}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_DivModNegativeDivisibleRegression(t *testing.T) {
+	// Div, Mod and DivMod in v0.2.0 and earlier rounded the quotient away from zero
+	// even if the remainder was zero: -4 div 2 returned -3, and -4 mod 2 returned 2.
+	testCases := []struct {
+		a, b     Int128
+		div, mod Int128
+	}{
+		{Int128{0, 4}.Neg(), Int128{0, 2}, Int128{0, 2}.Neg(), Int128{0, 0}},
+		{Int128{0, 4}.Neg(), Int128{0, 2}.Neg(), Int128{0, 2}, Int128{0, 0}},
+		{Int128{-1 << 63, 0}, Int128{0, 1}.Neg(), Int128{-1 << 63, 0}, Int128{0, 0}},
+	}
+	for i, tc := range testCases {
+		div, mod := tc.a.DivMod(tc.b)
+		if div != tc.div || mod != tc.mod {
+			t.Errorf("%d: %s divmod %s should (%s, %s), but (%s, %s)", i, tc.a, tc.b, tc.div, tc.mod, div, mod)
+		}
+		if got := tc.a.Div(tc.b); got != tc.div {
+			t.Errorf("%d: %s div %s should %s, but %s", i, tc.a, tc.b, tc.div, got)
+		}
+		if got := tc.a.Mod(tc.b); got != tc.mod {
+			t.Errorf("%d: %s mod %s should %s, but %s", i, tc.a, tc.b, tc.mod, got)
+		}
+	}
+}
+
 func BenchmarkInt128_DivMod(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		div, mod := int128Input.DivMod(int128Input)
@@ -473,6 +525,91 @@ func BenchmarkInt128_Rem(b *testing.B) {
 	}
 }
 
+func TestInt128_DivRound(t *testing.T) {
+	testCases := []struct {
+		a, b Int128
+		mode RoundingMode
+		want Int128
+	}{
+		{Int128{0, 5}, Int128{0, 2}, ToNearestEven, Int128{0, 2}},
+		{Int128{0, 5}, Int128{0, 2}.Neg(), ToNearestEven, Int128{0, 2}.Neg()},
+		{Int128{0, 5}.Neg(), Int128{0, 2}, ToNearestAway, Int128{0, 3}.Neg()},
+		{Int128{0, 7}.Neg(), Int128{0, 2}, ToNearestEven, Int128{0, 4}.Neg()},
+		{Int128{0, 7}.Neg(), Int128{0, 2}, ToZero, Int128{0, 3}.Neg()},
+		{Int128{0, 7}.Neg(), Int128{0, 2}, AwayFromZero, Int128{0, 4}.Neg()},
+		{Int128{0, 7}.Neg(), Int128{0, 2}, ToNegativeInf, Int128{0, 4}.Neg()},
+		{Int128{0, 7}.Neg(), Int128{0, 2}, ToPositiveInf, Int128{0, 3}.Neg()},
+		{Int128{0, 7}.Neg(), Int128{0, 2}.Neg(), ToNegativeInf, Int128{0, 3}},
+		{Int128{0, 7}.Neg(), Int128{0, 2}.Neg(), ToPositiveInf, Int128{0, 4}},
+		{Int128{-0x8000_0000_0000_0000, 0}, Int128{0, 3}, ToNearestEven, Int128{-0x2aaa_aaaa_aaaa_aaab, 0x5555_5555_5555_5555}},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.DivRound(tc.b, tc.mode)
+		if got != tc.want {
+			t.Errorf("%d: %#v / %#v (%s) should %#v, but %#v", i, tc.a, tc.b, tc.mode, tc.want, got)
+		}
+	}
+}
+
+func TestInt128_DivRoundQuick(t *testing.T) {
+	for _, mode := range roundingModes {
+		mode := mode
+		f := func(l uint, a, b Int128) Int128 {
+			b = b.Rsh(l % 128)
+			if b == (Int128{0, 0}) {
+				return Int128{0, 0}
+			}
+			return a.DivRound(b, mode)
+		}
+		g := func(l uint, a, b Int128) Int128 {
+			b = b.Rsh(l % 128)
+			if b == (Int128{0, 0}) {
+				return Int128{0, 0}
+			}
+			bigA := int128ToBig(new(big.Int), a)
+			bigB := int128ToBig(new(big.Int), b)
+			return bigToInt128(ratRound(new(big.Rat).SetFrac(bigA, bigB), mode))
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 1000,
+		}); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+	}
+}
+
+func TestInt128_DivFloorCeilQuick(t *testing.T) {
+	f := func(l uint, a, b Int128) (Int128, Int128) {
+		b = b.Rsh(l % 128)
+		if b == (Int128{0, 0}) {
+			return Int128{0, 0}, Int128{0, 0}
+		}
+		return a.DivFloor(b), a.DivCeil(b)
+	}
+	g := func(l uint, a, b Int128) (Int128, Int128) {
+		b = b.Rsh(l % 128)
+		if b == (Int128{0, 0}) {
+			return Int128{0, 0}, Int128{0, 0}
+		}
+		bigA := int128ToBig(new(big.Int), a)
+		bigB := int128ToBig(new(big.Int), b)
+		x := new(big.Rat).SetFrac(bigA, bigB)
+		return bigToInt128(ratRound(x, ToNegativeInf)), bigToInt128(ratRound(x, ToPositiveInf))
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkInt128_DivRound(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(int128Input.DivRound(int128Input, ToNearestEven))
+	}
+}
+
 func TestInt128_Cmp(t *testing.T) {
 	testCases := []struct {
 		a, b Int128
@@ -751,6 +888,100 @@ func BenchmarkInt128_Neg(b *testing.B) {
 	}
 }
 
+func TestInt128_Abs(t *testing.T) {
+	testCases := []struct {
+		a    Int128
+		abs  Int128
+		absU Uint128
+		sign int
+	}{
+		{Int128{0, 0}, Int128{0, 0}, Uint128{0, 0}, 0},
+		{Int128{0, 1}, Int128{0, 1}, Uint128{0, 1}, 1},
+		{Int128{-1, 0xffff_ffff_ffff_ffff}, Int128{0, 1}, Uint128{0, 1}, -1},
+		{Int128{-1, 0}, Int128{1, 0}, Uint128{1, 0}, -1},
+		{
+			// the max value of Int128
+			Int128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Int128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			1,
+		},
+		{
+			// the min value of Int128
+			Int128{-0x8000_0000_0000_0000, 0},
+			Int128{-0x8000_0000_0000_0000, 0},
+			Uint128{0x8000_0000_0000_0000, 0},
+			-1,
+		},
+	}
+
+	for i, tc := range testCases {
+		if got := tc.a.Abs(); got != tc.abs {
+			t.Errorf("%d: |%#v| should %#v, but %#v", i, tc.a, tc.abs, got)
+		}
+		if got := tc.a.AbsUint128(); got != tc.absU {
+			t.Errorf("%d: |%#v| should %#v, but %#v", i, tc.a, tc.absU, got)
+		}
+		if got := tc.a.Sign(); got != tc.sign {
+			t.Errorf("%d: sign of %#v should %d, but %d", i, tc.a, tc.sign, got)
+		}
+		if got := tc.a.IsNeg(); got != (tc.sign < 0) {
+			t.Errorf("%d: %#v.IsNeg() should %t, but %t", i, tc.a, tc.sign < 0, got)
+		}
+	}
+}
+
+func BenchmarkInt128_Abs(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(int128Input.Abs())
+	}
+}
+
+func TestInt128_CopySign(t *testing.T) {
+	testCases := []struct {
+		a, sign, want Int128
+	}{
+		{Int128{0, 1}, Int128{0, 0}, Int128{0, 1}},
+		{Int128{0, 1}, Int128{0, 5}, Int128{0, 1}},
+		{Int128{0, 1}, Int128{0, 5}.Neg(), Int128{0, 1}.Neg()},
+		{Int128{0, 1}.Neg(), Int128{0, 0}, Int128{0, 1}},
+		{Int128{0, 1}.Neg(), Int128{0, 5}.Neg(), Int128{0, 1}.Neg()},
+		{Int128{-0x8000_0000_0000_0000, 0}, Int128{0, 1}.Neg(), Int128{-0x8000_0000_0000_0000, 0}},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.CopySign(tc.sign)
+		if got != tc.want {
+			t.Errorf("%d: %#v.CopySign(%#v) should %#v, but %#v", i, tc.a, tc.sign, tc.want, got)
+		}
+	}
+}
+
+func TestInt128FromSignMagnitude(t *testing.T) {
+	testCases := []struct {
+		neg  bool
+		mag  Uint128
+		want Int128
+		ok   bool
+	}{
+		{false, Uint128{0, 0}, Int128{0, 0}, true},
+		{true, Uint128{0, 0}, Int128{0, 0}, true},
+		{false, Uint128{0, 1}, Int128{0, 1}, true},
+		{true, Uint128{0, 1}, Int128{-1, 0xffff_ffff_ffff_ffff}, true},
+		{false, Uint128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Int128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, true},
+		{false, Uint128{0x8000_0000_0000_0000, 0}, Int128{-0x8000_0000_0000_0000, 0}, false},
+		{true, Uint128{0x8000_0000_0000_0000, 0}, Int128{-0x8000_0000_0000_0000, 0}, true},
+		{true, Uint128{0x8000_0000_0000_0000, 1}, Int128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, false},
+	}
+
+	for i, tc := range testCases {
+		got, ok := Int128FromSignMagnitude(tc.neg, tc.mag)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%d: Int128FromSignMagnitude(%t, %#v) should (%#v, %t), but (%#v, %t)", i, tc.neg, tc.mag, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
 func TestInt128_Lsh(t *testing.T) {
 	testCases := []struct {
 		a    Int128
@@ -0,0 +1,8 @@
+module github.com/shogo82148/int128/int128pb
+
+go 1.18
+
+require (
+	github.com/shogo82148/int128 v0.2.1
+	google.golang.org/protobuf v1.33.0
+)
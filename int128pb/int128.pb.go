@@ -0,0 +1,232 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: int128pb/int128.proto
+
+package int128pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Int128 is a signed 128-bit integer.
+// The value is hi * 2**64 + lo in two's complement,
+// i.e. hi holds the upper 64 bits including the sign bit.
+type Int128 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The upper 64 bits of the value.
+	Hi uint64 `protobuf:"fixed64,1,opt,name=hi,proto3" json:"hi,omitempty"`
+	// The lower 64 bits of the value.
+	Lo uint64 `protobuf:"fixed64,2,opt,name=lo,proto3" json:"lo,omitempty"`
+}
+
+func (x *Int128) Reset() {
+	*x = Int128{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_int128pb_int128_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Int128) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int128) ProtoMessage() {}
+
+func (x *Int128) ProtoReflect() protoreflect.Message {
+	mi := &file_int128pb_int128_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int128.ProtoReflect.Descriptor instead.
+func (*Int128) Descriptor() ([]byte, []int) {
+	return file_int128pb_int128_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Int128) GetHi() uint64 {
+	if x != nil {
+		return x.Hi
+	}
+	return 0
+}
+
+func (x *Int128) GetLo() uint64 {
+	if x != nil {
+		return x.Lo
+	}
+	return 0
+}
+
+// Uint128 is an unsigned 128-bit integer.
+// The value is hi * 2**64 + lo.
+type Uint128 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The upper 64 bits of the value.
+	Hi uint64 `protobuf:"fixed64,1,opt,name=hi,proto3" json:"hi,omitempty"`
+	// The lower 64 bits of the value.
+	Lo uint64 `protobuf:"fixed64,2,opt,name=lo,proto3" json:"lo,omitempty"`
+}
+
+func (x *Uint128) Reset() {
+	*x = Uint128{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_int128pb_int128_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Uint128) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint128) ProtoMessage() {}
+
+func (x *Uint128) ProtoReflect() protoreflect.Message {
+	mi := &file_int128pb_int128_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint128.ProtoReflect.Descriptor instead.
+func (*Uint128) Descriptor() ([]byte, []int) {
+	return file_int128pb_int128_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Uint128) GetHi() uint64 {
+	if x != nil {
+		return x.Hi
+	}
+	return 0
+}
+
+func (x *Uint128) GetLo() uint64 {
+	if x != nil {
+		return x.Lo
+	}
+	return 0
+}
+
+var File_int128pb_int128_proto protoreflect.FileDescriptor
+
+var file_int128pb_int128_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x69, 0x6e, 0x74, 0x31, 0x32, 0x38, 0x70, 0x62, 0x2f, 0x69, 0x6e, 0x74, 0x31, 0x32,
+	0x38, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x73, 0x68, 0x6f, 0x67, 0x6f, 0x38, 0x32,
+	0x31, 0x34, 0x38, 0x2e, 0x69, 0x6e, 0x74, 0x31, 0x32, 0x38, 0x22, 0x28, 0x0a, 0x06, 0x49, 0x6e,
+	0x74, 0x31, 0x32, 0x38, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06,
+	0x52, 0x02, 0x68, 0x69, 0x12, 0x0e, 0x0a, 0x02, 0x6c, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x06,
+	0x52, 0x02, 0x6c, 0x6f, 0x22, 0x29, 0x0a, 0x07, 0x55, 0x69, 0x6e, 0x74, 0x31, 0x32, 0x38, 0x12,
+	0x0e, 0x0a, 0x02, 0x68, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x02, 0x68, 0x69, 0x12,
+	0x0e, 0x0a, 0x02, 0x6c, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x06, 0x52, 0x02, 0x6c, 0x6f, 0x42,
+	0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68,
+	0x6f, 0x67, 0x6f, 0x38, 0x32, 0x31, 0x34, 0x38, 0x2f, 0x69, 0x6e, 0x74, 0x31, 0x32, 0x38, 0x2f,
+	0x69, 0x6e, 0x74, 0x31, 0x32, 0x38, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_int128pb_int128_proto_rawDescOnce sync.Once
+	file_int128pb_int128_proto_rawDescData = file_int128pb_int128_proto_rawDesc
+)
+
+func file_int128pb_int128_proto_rawDescGZIP() []byte {
+	file_int128pb_int128_proto_rawDescOnce.Do(func() {
+		file_int128pb_int128_proto_rawDescData = protoimpl.X.CompressGZIP(file_int128pb_int128_proto_rawDescData)
+	})
+	return file_int128pb_int128_proto_rawDescData
+}
+
+var file_int128pb_int128_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_int128pb_int128_proto_goTypes = []interface{}{
+	(*Int128)(nil),  // 0: shogo82148.int128.Int128
+	(*Uint128)(nil), // 1: shogo82148.int128.Uint128
+}
+var file_int128pb_int128_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_int128pb_int128_proto_init() }
+func file_int128pb_int128_proto_init() {
+	if File_int128pb_int128_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_int128pb_int128_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Int128); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_int128pb_int128_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Uint128); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_int128pb_int128_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_int128pb_int128_proto_goTypes,
+		DependencyIndexes: file_int128pb_int128_proto_depIdxs,
+		MessageInfos:      file_int128pb_int128_proto_msgTypes,
+	}.Build()
+	File_int128pb_int128_proto = out.File
+	file_int128pb_int128_proto_rawDesc = nil
+	file_int128pb_int128_proto_goTypes = nil
+	file_int128pb_int128_proto_depIdxs = nil
+}
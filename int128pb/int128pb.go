@@ -0,0 +1,50 @@
+// Package int128pb provides the Protocol Buffers messages for int128.Int128 and int128.Uint128.
+//
+// The messages are defined in int128.proto, and carry the value as two fixed64 fields {hi, lo}.
+// Import "int128pb/int128.proto" in your .proto files to use them:
+//
+//	message Account {
+//	  shogo82148.int128.Uint128 id = 1;
+//	  shogo82148.int128.Int128 balance = 2;
+//	}
+//
+// Int128ToProto and Uint128ToProto convert the values into the messages,
+// and Int128FromProto and Uint128FromProto convert them back.
+//
+// # JSON
+//
+// [google.golang.org/protobuf/encoding/protojson] formats the messages as objects
+// like {"hi":"9223372036854775808", "lo":"0"}, because it supports custom JSON mappings
+// only for the well-known types.
+// Use MarshalJSON and UnmarshalJSON instead of protojson.Marshal and protojson.Unmarshal
+// to format the messages as decimal strings like "-170141183460469231731687303715884105728":
+//
+//	data, err := int128pb.MarshalJSON(&Account{Balance: int128pb.Int128ToProto(balance)})
+//	// data is {"balance":"-1000"} if balance is -1000
+package int128pb
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative ../int128pb/int128.proto
+
+import "github.com/shogo82148/int128"
+
+// Int128ToProto converts v to an Int128 message.
+func Int128ToProto(v int128.Int128) *Int128 {
+	return &Int128{Hi: uint64(v.H), Lo: v.L}
+}
+
+// Int128FromProto converts x to an int128.Int128.
+// A nil x is converted to zero.
+func Int128FromProto(x *Int128) int128.Int128 {
+	return int128.Int128{H: int64(x.GetHi()), L: x.GetLo()}
+}
+
+// Uint128ToProto converts v to a Uint128 message.
+func Uint128ToProto(v int128.Uint128) *Uint128 {
+	return &Uint128{Hi: v.H, Lo: v.L}
+}
+
+// Uint128FromProto converts x to an int128.Uint128.
+// A nil x is converted to zero.
+func Uint128FromProto(x *Uint128) int128.Uint128 {
+	return int128.Uint128{H: x.GetHi(), L: x.GetLo()}
+}
@@ -0,0 +1,77 @@
+package int128pb
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestInt128Quick(t *testing.T) {
+	f := func(v int128.Int128) bool {
+		data, err := proto.Marshal(Int128ToProto(v))
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		var x Int128
+		if err := proto.Unmarshal(data, &x); err != nil {
+			t.Error(err)
+			return false
+		}
+		return Int128FromProto(&x) == v
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128Quick(t *testing.T) {
+	f := func(v int128.Uint128) bool {
+		data, err := proto.Marshal(Uint128ToProto(v))
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		var x Uint128
+		if err := proto.Unmarshal(data, &x); err != nil {
+			t.Error(err)
+			return false
+		}
+		return Uint128FromProto(&x) == v
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWireFormat(t *testing.T) {
+	// the messages must be compatible with the messages that have two fixed64 fields {hi, lo}.
+	v := int128.Int128{H: -2, L: 0x1234_5678_9abc_def0}
+	var want []byte
+	want = protowire.AppendTag(want, 1, protowire.Fixed64Type)
+	want = protowire.AppendFixed64(want, uint64(v.H))
+	want = protowire.AppendTag(want, 2, protowire.Fixed64Type)
+	want = protowire.AppendFixed64(want, v.L)
+
+	got, err := proto.Marshal(Int128ToProto(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("want %x, got %x", want, got)
+	}
+}
+
+func TestNil(t *testing.T) {
+	var x *Int128
+	if got := Int128FromProto(x); got != (int128.Int128{}) {
+		t.Errorf("want zero, got %#v", got)
+	}
+	var y *Uint128
+	if got := Uint128FromProto(y); got != (int128.Uint128{}) {
+		t.Errorf("want zero, got %#v", got)
+	}
+}
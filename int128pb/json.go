@@ -0,0 +1,300 @@
+package int128pb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/shogo82148/int128"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	int128Name  protoreflect.FullName = "shogo82148.int128.Int128"
+	uint128Name protoreflect.FullName = "shogo82148.int128.Uint128"
+)
+
+// MarshalJSON converts m into JSON like [protojson.Marshal],
+// but it formats the Int128 and Uint128 messages in m as decimal strings
+// like "-170141183460469231731687303715884105728" instead of {"hi": ..., "lo": ...} objects.
+//
+// The messages inside google.protobuf.Any are kept as objects.
+func MarshalJSON(m proto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	v, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	v = formatMessage(m.ProtoReflect(), v)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalJSON parses data into m like [protojson.Unmarshal].
+// It accepts the Int128 and Uint128 messages as decimal strings, JSON numbers,
+// and the {"hi": ..., "lo": ...} objects that protojson.Marshal generates.
+func UnmarshalJSON(data []byte, m proto.Message) error {
+	v, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+	v, err = parseMessage(m.ProtoReflect().Descriptor(), v)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, m)
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err == nil {
+		return nil, errors.New("int128pb: unexpected data after the top-level value")
+	}
+	return v, nil
+}
+
+// formatMessage replaces the Int128 and Uint128 messages in v, the protojson form of m, with decimal strings.
+func formatMessage(m protoreflect.Message, v interface{}) interface{} {
+	md := m.Descriptor()
+	switch md.FullName() {
+	case int128Name:
+		hi, lo := m.Get(md.Fields().ByNumber(1)).Uint(), m.Get(md.Fields().ByNumber(2)).Uint()
+		return int128.Int128{H: int64(hi), L: lo}.String()
+	case uint128Name:
+		hi, lo := m.Get(md.Fields().ByNumber(1)).Uint(), m.Get(md.Fields().ByNumber(2)).Uint()
+		return int128.Uint128{H: hi, L: lo}.String()
+	}
+	if md.FullName().Parent() == "google.protobuf" {
+		// the well-known types have their own JSON mappings.
+		return v
+	}
+
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		if fd.IsExtension() {
+			return true
+		}
+		key := fd.JSONName()
+		fv, ok := obj[key]
+		if !ok {
+			return true
+		}
+		switch {
+		case fd.IsList():
+			if fd.Message() == nil {
+				return true
+			}
+			arr, ok := fv.([]interface{})
+			if !ok {
+				return true
+			}
+			list := val.List()
+			for i := 0; i < list.Len() && i < len(arr); i++ {
+				arr[i] = formatMessage(list.Get(i).Message(), arr[i])
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			mobj, ok := fv.(map[string]interface{})
+			if !ok {
+				return true
+			}
+			val.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				name := k.String()
+				if ev, ok := mobj[name]; ok {
+					mobj[name] = formatMessage(mv.Message(), ev)
+				}
+				return true
+			})
+		case fd.Message() != nil:
+			obj[key] = formatMessage(val.Message(), fv)
+		}
+		return true
+	})
+	return obj
+}
+
+// parseMessage replaces the decimal strings in v, the JSON form of md, with the protojson form of Int128 and Uint128.
+func parseMessage(md protoreflect.MessageDescriptor, v interface{}) (interface{}, error) {
+	switch md.FullName() {
+	case int128Name:
+		s, ok := decimal(v)
+		if !ok {
+			return v, nil
+		}
+		x, err := parseInt128(s)
+		if err != nil {
+			return nil, err
+		}
+		return protoObject(uint64(x.H), x.L), nil
+	case uint128Name:
+		s, ok := decimal(v)
+		if !ok {
+			return v, nil
+		}
+		x, err := parseUint128(s)
+		if err != nil {
+			return nil, err
+		}
+		return protoObject(x.H, x.L), nil
+	}
+	if md.FullName().Parent() == "google.protobuf" {
+		return v, nil
+	}
+
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return v, nil
+	}
+	fields := md.Fields()
+	for key, fv := range obj {
+		fd := fields.ByJSONName(key)
+		if fd == nil {
+			fd = fields.ByTextName(key)
+		}
+		if fd == nil {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			if fd.Message() == nil {
+				continue
+			}
+			arr, ok := fv.([]interface{})
+			if !ok {
+				continue
+			}
+			for i, ev := range arr {
+				ev, err := parseMessage(fd.Message(), ev)
+				if err != nil {
+					return nil, err
+				}
+				arr[i] = ev
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			mobj, ok := fv.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for k, ev := range mobj {
+				ev, err := parseMessage(fd.MapValue().Message(), ev)
+				if err != nil {
+					return nil, err
+				}
+				mobj[k] = ev
+			}
+		case fd.Message() != nil:
+			fv, err := parseMessage(fd.Message(), fv)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = fv
+		}
+	}
+	return obj, nil
+}
+
+func decimal(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return string(v), true
+	}
+	return "", false
+}
+
+func protoObject(hi, lo uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"hi": strconv.FormatUint(hi, 10),
+		"lo": strconv.FormatUint(lo, 10),
+	}
+}
+
+// parseUint128 parses a decimal string.
+// The released int128 module doesn't have a parser yet, so we have our own here.
+func parseUint128(s string) (int128.Uint128, error) {
+	if s == "" {
+		return int128.Uint128{}, fmt.Errorf("int128pb: invalid Uint128 %q", s)
+	}
+	var h, l uint64
+	for rest := s; rest != ""; {
+		n := len(rest)
+		if n > 19 {
+			n = 19
+		}
+		d, err := strconv.ParseUint(rest[:n], 10, 64)
+		if err != nil {
+			return int128.Uint128{}, fmt.Errorf("int128pb: invalid Uint128 %q", s)
+		}
+		mul := uint64(1)
+		for i := 0; i < n; i++ {
+			mul *= 10
+		}
+
+		// (h, l) = (h, l) * mul + d
+		ph, pl := bits.Mul64(h, mul)
+		hh, ll := bits.Mul64(l, mul)
+		var c1, c2 uint64
+		hh, c1 = bits.Add64(hh, pl, 0)
+		ll, c2 = bits.Add64(ll, d, 0)
+		hh, c2 = bits.Add64(hh, 0, c2)
+		if ph != 0 || c1 != 0 || c2 != 0 {
+			return int128.Uint128{}, fmt.Errorf("int128pb: Uint128 %q out of range", s)
+		}
+		h, l = hh, ll
+		rest = rest[n:]
+	}
+	return int128.Uint128{H: h, L: l}, nil
+}
+
+func parseInt128(s string) (int128.Int128, error) {
+	neg := strings.HasPrefix(s, "-")
+	u, err := parseUint128(strings.TrimPrefix(s, "-"))
+	if err != nil {
+		return int128.Int128{}, fmt.Errorf("int128pb: invalid Int128 %q", s)
+	}
+	if neg {
+		if u.H > 1<<63 || (u.H == 1<<63 && u.L != 0) {
+			return int128.Int128{}, fmt.Errorf("int128pb: Int128 %q out of range", s)
+		}
+		l, borrow := bits.Sub64(0, u.L, 0)
+		h, _ := bits.Sub64(0, u.H, borrow)
+		return int128.Int128{H: int64(h), L: l}, nil
+	}
+	if u.H>>63 != 0 {
+		return int128.Int128{}, fmt.Errorf("int128pb: Int128 %q out of range", s)
+	}
+	return int128.Int128{H: int64(u.H), L: u.L}, nil
+}
@@ -0,0 +1,264 @@
+package int128pb
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// accountDescriptor returns the descriptor of the message:
+//
+//	message Account {
+//	  shogo82148.int128.Uint128 id = 1;
+//	  shogo82148.int128.Int128 balance = 2;
+//	  repeated shogo82148.int128.Int128 history = 3;
+//	  map<string, shogo82148.int128.Uint128> limits = 4;
+//	  Account parent = 5;
+//	  string name = 6;
+//	}
+func accountDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		}
+		if typeName == "" {
+			f.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+		} else {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("int128pb/account_test.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{File_int128pb_int128_proto.Path()},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Account"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, optional, ".shogo82148.int128.Uint128"),
+					field("balance", 2, optional, ".shogo82148.int128.Int128"),
+					field("history", 3, repeated, ".shogo82148.int128.Int128"),
+					field("limits", 4, repeated, ".test.Account.LimitsEntry"),
+					field("parent", 5, optional, ".test.Account"),
+					field("name", 6, optional, ""),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("LimitsEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("key", 1, optional, ""),
+							field("value", 2, optional, ".shogo82148.int128.Uint128"),
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+				},
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd.Messages().ByName("Account")
+}
+
+func newAccount(t *testing.T) *dynamicpb.Message {
+	t.Helper()
+	md := accountDescriptor(t)
+	fields := md.Fields()
+	m := dynamicpb.NewMessage(md)
+	m.Set(fields.ByName("id"), protoreflect.ValueOfMessage(Uint128ToProto(int128.Uint128{H: math.MaxUint64, L: math.MaxUint64}).ProtoReflect()))
+	m.Set(fields.ByName("balance"), protoreflect.ValueOfMessage(Int128ToProto(int128.Int128{H: math.MinInt64, L: 0}).ProtoReflect()))
+
+	history := m.Mutable(fields.ByName("history")).List()
+	history.Append(protoreflect.ValueOfMessage(Int128ToProto(int128.Int128{H: -1, L: math.MaxUint64}).ProtoReflect()))
+	history.Append(protoreflect.ValueOfMessage(Int128ToProto(int128.Int128{H: 0, L: 0}).ProtoReflect()))
+
+	limits := m.Mutable(fields.ByName("limits")).Map()
+	limits.Set(protoreflect.ValueOfString("daily").MapKey(), protoreflect.ValueOfMessage(Uint128ToProto(int128.Uint128{H: 1, L: 0}).ProtoReflect()))
+
+	parent := dynamicpb.NewMessage(md)
+	parent.Set(fields.ByName("balance"), protoreflect.ValueOfMessage(Int128ToProto(int128.Int128{H: 0, L: 42}).ProtoReflect()))
+	parent.Set(fields.ByName("name"), protoreflect.ValueOfString("<parent>"))
+	m.Set(fields.ByName("parent"), protoreflect.ValueOfMessage(parent))
+	return m
+}
+
+func TestMarshalJSON(t *testing.T) {
+	m := newAccount(t)
+	got, err := MarshalJSON(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"id":      "340282366920938463463374607431768211455",
+		"balance": "-170141183460469231731687303715884105728",
+		"history": []interface{}{"-1", "0"},
+		"limits": map[string]interface{}{
+			"daily": "18446744073709551616",
+		},
+		"parent": map[string]interface{}{
+			"balance": "42",
+			"name":    "<parent>",
+		},
+	}
+	var v interface{}
+	if err := json.Unmarshal(got, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("unexpected JSON: %s", got)
+	}
+
+	// round trip
+	x := dynamicpb.NewMessage(m.Descriptor())
+	if err := UnmarshalJSON(got, x); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(m, x) {
+		t.Errorf("want %v, got %v", m, x)
+	}
+}
+
+func TestMarshalJSON_TopLevel(t *testing.T) {
+	got, err := MarshalJSON(Int128ToProto(int128.Int128{H: -1, L: math.MaxUint64 - 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `"-2"` {
+		t.Errorf("want %q, got %q", `"-2"`, got)
+	}
+
+	got, err = MarshalJSON(Uint128ToProto(int128.Uint128{H: 0, L: 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `"0"` {
+		t.Errorf("want %q, got %q", `"0"`, got)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	m := newAccount(t)
+	md := m.Descriptor()
+
+	// UnmarshalJSON accepts JSON numbers, proto names and the objects generated by protojson.
+	input := `{
+		"id": 340282366920938463463374607431768211455,
+		"balance": {"hi": "9223372036854775808", "lo": "0"},
+		"history": ["-1", 0],
+		"limits": {"daily": "18446744073709551616"},
+		"parent": {"balance": "42", "name": "<parent>"}
+	}`
+	x := dynamicpb.NewMessage(md)
+	if err := UnmarshalJSON([]byte(input), x); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(m, x) {
+		t.Errorf("want %v, got %v", m, x)
+	}
+
+	// the output of protojson.Marshal
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x = dynamicpb.NewMessage(md)
+	if err := UnmarshalJSON(data, x); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(m, x) {
+		t.Errorf("want %v, got %v", m, x)
+	}
+}
+
+func TestUnmarshalJSON_Error(t *testing.T) {
+	testCases := []struct {
+		input string
+		msg   proto.Message
+	}{
+		{`""`, &Int128{}},
+		{`"+1"`, &Int128{}},
+		{`"1.0"`, &Int128{}},
+		{`1e3`, &Int128{}},
+		{`"--1"`, &Int128{}},
+		{`"170141183460469231731687303715884105728"`, &Int128{}},
+		{`"-170141183460469231731687303715884105729"`, &Int128{}},
+		{`"-1"`, &Uint128{}},
+		{`"340282366920938463463374607431768211456"`, &Uint128{}},
+		{`"1000000000000000000000000000000000000000"`, &Uint128{}},
+		{`"1" "2"`, &Uint128{}},
+	}
+	for _, tc := range testCases {
+		if err := UnmarshalJSON([]byte(tc.input), tc.msg); err == nil {
+			t.Errorf("%s: want error, got nil", tc.input)
+		}
+	}
+}
+
+func TestInt128JSONQuick(t *testing.T) {
+	f := func(v int128.Int128) bool {
+		data, err := MarshalJSON(Int128ToProto(v))
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		if string(data) != `"`+v.String()+`"` {
+			t.Errorf("want %q, got %q", v.String(), data)
+			return false
+		}
+		var x Int128
+		if err := UnmarshalJSON(data, &x); err != nil {
+			t.Error(err)
+			return false
+		}
+		return Int128FromProto(&x) == v
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128JSONQuick(t *testing.T) {
+	f := func(v int128.Uint128) bool {
+		data, err := MarshalJSON(Uint128ToProto(v))
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		if string(data) != `"`+v.String()+`"` {
+			t.Errorf("want %q, got %q", v.String(), data)
+			return false
+		}
+		var x Uint128
+		if err := UnmarshalJSON(data, &x); err != nil {
+			t.Error(err)
+			return false
+		}
+		return Uint128FromProto(&x) == v
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
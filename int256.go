@@ -0,0 +1,240 @@
+package int128
+
+// Int256 is a signed 256-bit integer.
+type Int256 struct {
+	H Int128
+	L Uint128
+}
+
+// Add returns the sum a+b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Add(b Int256) Int256 {
+	return a.Uint256().Add(b.Uint256()).Int256()
+}
+
+// Sub returns the difference x-y.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Sub(b Int256) Int256 {
+	return a.Uint256().Sub(b.Uint256()).Int256()
+}
+
+// Mul returns the product x*y.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Mul(b Int256) Int256 {
+	// the lower 256 bits of the product are the same as the unsigned one.
+	return a.Uint256().Mul(b.Uint256()).Int256()
+}
+
+// Div returns the quotient a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// Div implements Euclidean division (unlike Go); see DivMod for more details.
+func (a Int256) Div(b Int256) Int256 {
+	q, _ := a.DivMod(b)
+	return q
+}
+
+// Mod returns the modulus x%y for y != 0.
+// If y == 0, a division-by-zero run-time panic occurs.
+// Mod implements Euclidean modulus (unlike Go); see DivMod for more details.
+func (a Int256) Mod(b Int256) Int256 {
+	_, m := a.DivMod(b)
+	return m
+}
+
+// DivMod returns the quotient and remainder of a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+//
+// DivMod implements Euclidean division and modulus (unlike Go):
+//
+//	q = a div b  such that
+//	m = a - b*q  with 0 <= m < |y|
+func (a Int256) DivMod(b Int256) (Int256, Int256) {
+	negA, negB := a.IsNeg(), b.IsNeg()
+	absB := b.AbsUint256()
+
+	div, mod := a.AbsUint256().DivMod(absB)
+	if negA && !mod.IsZero() {
+		mod = absB.Sub(mod)
+		div = div.Add(Uint256{Uint128{}, Uint128{0, 1}})
+	}
+	if negA != negB {
+		div = div.Neg()
+	}
+	return div.Int256(), mod.Int256()
+}
+
+// Quo returns the quotient a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// Quo implements truncated division (like Go); see QuoRem for more details.
+func (a Int256) Quo(b Int256) Int256 {
+	q, _ := a.QuoRem(b)
+	return q
+}
+
+// Rem returns he remainder a%b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// Rem implements truncated modulus (like Go); see QuoRem for more details.
+func (a Int256) Rem(b Int256) Int256 {
+	_, r := a.QuoRem(b)
+	return r
+}
+
+// QuoRem returns the quotient a/b and the remainder a%b for b != 0.
+// a division-by-zero run-time panic occurs.
+//
+// QuoRem implements T-division and modulus (like Go):
+//
+//	q = a/b      with the result truncated to zero
+//	r = a - b*q
+func (a Int256) QuoRem(b Int256) (Int256, Int256) {
+	negA, negB := a.IsNeg(), b.IsNeg()
+
+	div, mod := a.AbsUint256().DivMod(b.AbsUint256())
+	if negA != negB {
+		div = div.Neg()
+	}
+	if negA {
+		mod = mod.Neg()
+	}
+	return div.Int256(), mod.Int256()
+}
+
+// Cmp compares a and b and returns:
+//
+//	-1 if a <  b
+//	 0 if a == b
+//	+1 if a >  b
+func (a Int256) Cmp(b Int256) int {
+	if c := a.H.Cmp(b.H); c != 0 {
+		return c
+	}
+	return a.L.Cmp(b.L)
+}
+
+// And returns the bitwise AND a&b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) And(b Int256) Int256 {
+	return Int256{a.H.And(b.H), a.L.And(b.L)}
+}
+
+// Or returns the bitwise OR a|b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Or(b Int256) Int256 {
+	return Int256{a.H.Or(b.H), a.L.Or(b.L)}
+}
+
+// Xor returns the bitwise XOR a^b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Xor(b Int256) Int256 {
+	return Int256{a.H.Xor(b.H), a.L.Xor(b.L)}
+}
+
+// AndNot returns the bitwise AND NOT a&^b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) AndNot(b Int256) Int256 {
+	return Int256{a.H.AndNot(b.H), a.L.AndNot(b.L)}
+}
+
+// Not returns the bitwise NOT ^a.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Not() Int256 {
+	return Int256{a.H.Not(), a.L.Not()}
+}
+
+// Neg returns the negation -a.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Neg() Int256 {
+	return a.Uint256().Neg().Int256()
+}
+
+// Lsh returns the logical left shift a<<i.
+//
+// This function's execution time does not depend on the inputs.
+func (a Int256) Lsh(i uint) Int256 {
+	return a.Uint256().Lsh(i).Int256()
+}
+
+// Rsh returns the arithmetic right shift a>>i.
+func (a Int256) Rsh(i uint) Int256 {
+	if i < 128 {
+		// a.H.Uint128().Lsh(128 - i) is 0 when i == 0.
+		return Int256{a.H.Rsh(i), a.L.Rsh(i).Or(a.H.Uint128().Lsh(128 - i))}
+	}
+	return Int256{a.H.Rsh(127), a.H.Rsh(i - 128).Uint128()}
+}
+
+// Abs returns the absolute value |a|.
+// The absolute value of the minimum value of Int256 cannot be represented,
+// so Abs returns a unchanged in that case. Use AbsUint256 to get the correct magnitude.
+func (a Int256) Abs() Int256 {
+	if a.IsNeg() {
+		return a.Neg()
+	}
+	return a
+}
+
+// AbsUint256 returns the absolute value |a| as an unsigned 256-bit integer.
+// Unlike Abs, the result is correct for the minimum value of Int256.
+func (a Int256) AbsUint256() Uint256 {
+	return a.Abs().Uint256()
+}
+
+// Sign returns:
+//
+//	-1 if a <  0
+//	 0 if a == 0
+//	+1 if a >  0
+func (a Int256) Sign() int {
+	if a.IsNeg() {
+		return -1
+	}
+	if a.Uint256().IsZero() {
+		return 0
+	}
+	return 1
+}
+
+// IsNeg reports whether a is negative.
+func (a Int256) IsNeg() bool {
+	return a.H.IsNeg()
+}
+
+// Uint256 returns a as a unsigned 256-bit integer.
+func (a Int256) Uint256() Uint256 {
+	return Uint256{a.H.Uint128(), a.L}
+}
+
+// Int256 returns a as a signed 256-bit integer.
+func (a Int128) Int256() Int256 {
+	return Int256{a.Rsh(127), a.Uint128()}
+}
+
+// Text returns the string representation of a in the given base.
+// Base must be between 2 and 36, inclusive.
+// The result uses the lower-case letters 'a' to 'z' for digit values 10 to 35.
+// No prefix (such as "0x") is added to the string.
+func (a Int256) Text(base int) string {
+	_, s := formatUint256(nil, a.AbsUint256(), base, a.IsNeg(), false)
+	return s
+}
+
+// Append appends the string representation of a, as generated by a.Text(base), to buf and returns the extended buffer.
+func (a Int256) Append(dst []byte, base int) []byte {
+	d, _ := formatUint256(dst, a.AbsUint256(), base, a.IsNeg(), true)
+	return d
+}
+
+// String returns the decimal representation of a as generated by a.Text(10).
+func (a Int256) String() string {
+	_, s := formatUint256(nil, a.AbsUint256(), 10, a.IsNeg(), false)
+	return s
+}
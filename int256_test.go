@@ -0,0 +1,256 @@
+package int128
+
+import (
+	"math/big"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+// int256Input is used for benchmarks to prevent compiler optimizations.
+var int256Input = Int256{Int128{0, 42}, Uint128{0, 42}}
+
+var bigMaxInt256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+
+// int256ToBig converts x to a big.Int.
+func int256ToBig(b *big.Int, x Int256) *big.Int {
+	b = uint256ToBig(b, x.Uint256())
+	if x.IsNeg() {
+		b.Sub(b, bigModUint256)
+	}
+	return b
+}
+
+// bigToInt256 converts x to an Int256.
+func bigToInt256(x *big.Int) Int256 {
+	return bigToUint256(x).Int256()
+}
+
+func TestInt256_AddSubMulQuick(t *testing.T) {
+	f := func(a, b Int256) [3]Int256 {
+		return [3]Int256{a.Add(b), a.Sub(b), a.Mul(b)}
+	}
+	g := func(a, b Int256) [3]Int256 {
+		bigA := int256ToBig(nil, a)
+		bigB := int256ToBig(nil, b)
+		return [3]Int256{
+			bigToInt256(new(big.Int).Add(bigA, bigB)),
+			bigToInt256(new(big.Int).Sub(bigA, bigB)),
+			bigToInt256(new(big.Int).Mul(bigA, bigB)),
+		}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkInt256_Mul(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(int256Input.Mul(int256Input))
+	}
+}
+
+func TestInt256_DivMod(t *testing.T) {
+	testCases := []struct {
+		a, b, div, mod int64
+	}{
+		{5, 3, 1, 2},
+		{-5, 3, -2, 1},
+		{5, -3, -1, 2},
+		{-5, -3, 2, 1},
+		{-4, 2, -2, 0},
+		{4, -2, -2, 0},
+		{-6, -3, 2, 0},
+	}
+
+	for i, tc := range testCases {
+		a, b := int256FromInt64(tc.a), int256FromInt64(tc.b)
+		div, mod := a.DivMod(b)
+		if want := int256FromInt64(tc.div); div != want {
+			t.Errorf("%d: %d div %d should %d, but %d", i, tc.a, tc.b, tc.div, div)
+		}
+		if want := int256FromInt64(tc.mod); mod != want {
+			t.Errorf("%d: %d mod %d should %d, but %d", i, tc.a, tc.b, tc.mod, mod)
+		}
+	}
+}
+
+// int256FromInt64 converts v to an Int256 for tests.
+func int256FromInt64(v int64) Int256 {
+	return bigToInt256(big.NewInt(v))
+}
+
+func TestInt256_DivModQuick(t *testing.T) {
+	f := func(a, b Int256) [2]Int256 {
+		if b.Sign() == 0 {
+			return [2]Int256{}
+		}
+		div, mod := a.DivMod(b)
+		return [2]Int256{div, mod}
+	}
+	g := func(a, b Int256) [2]Int256 {
+		if b.Sign() == 0 {
+			return [2]Int256{}
+		}
+		bigA := int256ToBig(nil, a)
+		bigB := int256ToBig(nil, b)
+		div, mod := new(big.Int).DivMod(bigA, bigB, new(big.Int))
+		return [2]Int256{bigToInt256(div), bigToInt256(mod)}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	// the random inputs above rarely have small divisors.
+	f2 := func(a Int256, b Int128) [2]Int256 {
+		return f(a, b.Int256())
+	}
+	g2 := func(a Int256, b Int128) [2]Int256 {
+		return g(a, b.Int256())
+	}
+	if err := quick.CheckEqual(f2, g2, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkInt256_DivMod(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		div, mod := int256Input.DivMod(int256Input)
+		runtime.KeepAlive(div)
+		runtime.KeepAlive(mod)
+	}
+}
+
+func TestInt256_QuoRemQuick(t *testing.T) {
+	f := func(a, b Int256) [2]Int256 {
+		if b.Sign() == 0 {
+			return [2]Int256{}
+		}
+		quo, rem := a.QuoRem(b)
+		return [2]Int256{quo, rem}
+	}
+	g := func(a, b Int256) [2]Int256 {
+		if b.Sign() == 0 {
+			return [2]Int256{}
+		}
+		bigA := int256ToBig(nil, a)
+		bigB := int256ToBig(nil, b)
+		quo, rem := new(big.Int).QuoRem(bigA, bigB, new(big.Int))
+		return [2]Int256{bigToInt256(quo), bigToInt256(rem)}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt256_CmpQuick(t *testing.T) {
+	f := func(a, b Int256) [2]int {
+		return [2]int{a.Cmp(b), a.Sign()}
+	}
+	g := func(a, b Int256) [2]int {
+		bigA := int256ToBig(nil, a)
+		return [2]int{bigA.Cmp(int256ToBig(nil, b)), bigA.Sign()}
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt256_ShiftQuick(t *testing.T) {
+	f := func(a Int256, i uint16) [2]Int256 {
+		n := uint(i % 300)
+		return [2]Int256{a.Lsh(n), a.Rsh(n)}
+	}
+	g := func(a Int256, i uint16) [2]Int256 {
+		n := uint(i % 300)
+		bigA := int256ToBig(nil, a)
+		return [2]Int256{
+			bigToInt256(new(big.Int).Lsh(bigA, n)),
+			bigToInt256(new(big.Int).Rsh(bigA, n)),
+		}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt256_Abs(t *testing.T) {
+	min := Int256{H: Int128{-1 << 63, 0}}
+	if got := min.Abs(); got != min {
+		t.Errorf("Abs(%#v) should %#v, but %#v", min, min, got)
+	}
+	if got, want := min.AbsUint256(), (Uint256{H: Uint128{1 << 63, 0}}); got != want {
+		t.Errorf("AbsUint256(%#v) should %#v, but %#v", min, want, got)
+	}
+
+	f := func(a Int256) Uint256 {
+		return a.AbsUint256()
+	}
+	g := func(a Int256) Uint256 {
+		return bigToUint256(new(big.Int).Abs(int256ToBig(nil, a)))
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt256_TextQuick(t *testing.T) {
+	for _, base := range []int{2, 8, 10, 16, 36} {
+		f := func(a Int256) string {
+			return a.Text(base)
+		}
+		g := func(a Int256) string {
+			return int256ToBig(nil, a).Text(base)
+		}
+		if err := quick.CheckEqual(f, g, nil); err != nil {
+			t.Errorf("base %d: %v", base, err)
+		}
+	}
+}
+
+func TestInt256_String(t *testing.T) {
+	max := bigToInt256(bigMaxInt256)
+	min := max.Add(Int256{L: Uint128{0, 1}})
+	testCases := []struct {
+		a    Int256
+		want string
+	}{
+		{Int256{}, "0"},
+		{int256FromInt64(-1), "-1"},
+		{max, "57896044618658097711785492504343953926634992332820282019728792003956564819967"},
+		{min, "-57896044618658097711785492504343953926634992332820282019728792003956564819968"},
+	}
+	for i, tc := range testCases {
+		if got := tc.a.String(); got != tc.want {
+			t.Errorf("%d: want %q, got %q", i, tc.want, got)
+		}
+	}
+}
+
+func BenchmarkInt256_String(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(int256Input.String())
+	}
+}
+
+func TestInt128_Int256Quick(t *testing.T) {
+	f := func(a Int128) Int256 {
+		return a.Int256()
+	}
+	g := func(a Int128) Int256 {
+		return bigToInt256(int128ToBig(nil, a))
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,155 @@
+// Package arith provides the 256-bit arithmetic shared by the int128 package and its subpackages.
+package arith
+
+import "math/bits"
+
+// Uint128 is a 128-bit unsigned integer.
+// It has the same layout as int128.Uint128, so they are convertible to each other.
+type Uint128 struct {
+	H uint64
+	L uint64
+}
+
+// Mul128 returns the 256-bit product of x and y: (hi, lo) = x * y
+// with the product bits' upper half returned in hi and the lower half returned in lo.
+//
+// This function's execution time does not depend on the inputs.
+func Mul128(x, y Uint128) (hi, lo Uint128) {
+	h00, l00 := bits.Mul64(x.L, y.L)
+	h01, l01 := bits.Mul64(x.L, y.H)
+	h10, l10 := bits.Mul64(x.H, y.L)
+	h11, l11 := bits.Mul64(x.H, y.H)
+
+	w1, c1 := bits.Add64(h00, l01, 0)
+	w1, c2 := bits.Add64(w1, l10, 0)
+	w2, c3 := bits.Add64(h01, h10, 0)
+	w2, c4 := bits.Add64(w2, l11, 0)
+	w2, c5 := bits.Add64(w2, c1+c2, 0)
+	w3 := h11 + c3 + c4 + c5
+	return Uint128{w3, w2}, Uint128{w1, l00}
+}
+
+// Div128 returns the quotient and remainder of (hi, lo) divided by y:
+// quo = (hi, lo)/y, rem = (hi, lo)%y with the dividend bits' upper
+// half in parameter hi and the lower half in parameter lo.
+// Div128 panics for y == 0 (division by zero) or y <= hi (quotient overflow).
+func Div128(hi, lo, y Uint128) (quo, rem Uint128) {
+	if y.H == 0 && y.L == 0 {
+		panic("int128: division by zero")
+	}
+	if cmp(y, hi) <= 0 {
+		panic("int128: integer overflow")
+	}
+
+	if y.H == 0 {
+		// optimize for uint256 / uint64
+		// hi < y, so hi.H is always zero.
+		q1, r := bits.Div64(hi.L, lo.H, y.L)
+		q0, r := bits.Div64(r, lo.L, y.L)
+		return Uint128{q1, q0}, Uint128{0, r}
+	}
+
+	// This is the same algorithm as bits.Div64,
+	// but the digits are 64 bits instead of 32 bits.
+	s := uint(bits.LeadingZeros64(y.H))
+	y = lsh(y, s)
+	yn1 := y.H
+	yn0 := y.L
+	un32 := lsh(hi, s)
+	if s != 0 {
+		un32.L |= lo.H >> (64 - s)
+	}
+	un10 := lsh(lo, s)
+	un1 := un10.H
+	un0 := un10.L
+
+	q1, rhat := divmod64(un32, yn1)
+	for {
+		if q1.H == 0 {
+			h, l := bits.Mul64(q1.L, yn0)
+			if cmp(Uint128{h, l}, Uint128{rhat.L, un1}) <= 0 {
+				break
+			}
+		}
+		q1 = sub(q1, Uint128{0, 1})
+		rhat = add(rhat, Uint128{0, yn1})
+		if rhat.H != 0 {
+			break
+		}
+	}
+
+	un21 := sub(Uint128{un32.L, un1}, mul(q1, y))
+	q0, rhat := divmod64(un21, yn1)
+	for {
+		if q0.H == 0 {
+			h, l := bits.Mul64(q0.L, yn0)
+			if cmp(Uint128{h, l}, Uint128{rhat.L, un0}) <= 0 {
+				break
+			}
+		}
+		q0 = sub(q0, Uint128{0, 1})
+		rhat = add(rhat, Uint128{0, yn1})
+		if rhat.H != 0 {
+			break
+		}
+	}
+
+	return Uint128{q1.L, q0.L}, rsh(sub(Uint128{un21.L, un0}, mul(q0, y)), s)
+}
+
+func add(a, b Uint128) Uint128 {
+	l, carry := bits.Add64(a.L, b.L, 0)
+	h, _ := bits.Add64(a.H, b.H, carry)
+	return Uint128{h, l}
+}
+
+func sub(a, b Uint128) Uint128 {
+	l, borrow := bits.Sub64(a.L, b.L, 0)
+	h, _ := bits.Sub64(a.H, b.H, borrow)
+	return Uint128{h, l}
+}
+
+// mul returns the lower 128 bits of a*b.
+func mul(a, b Uint128) Uint128 {
+	h, l := bits.Mul64(a.L, b.L)
+	return Uint128{h + a.H*b.L + a.L*b.H, l}
+}
+
+func cmp(a, b Uint128) int {
+	if a.H != b.H {
+		if a.H < b.H {
+			return -1
+		}
+		return 1
+	}
+	if a.L != b.L {
+		if a.L < b.L {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// lsh returns a<<s for s < 64.
+func lsh(a Uint128, s uint) Uint128 {
+	if s == 0 {
+		return a
+	}
+	return Uint128{a.H<<s | a.L>>(64-s), a.L << s}
+}
+
+// rsh returns a>>s for s < 64.
+func rsh(a Uint128, s uint) Uint128 {
+	if s == 0 {
+		return a
+	}
+	return Uint128{a.H >> s, a.L>>s | a.H<<(64-s)}
+}
+
+// divmod64 returns the quotient and the remainder of a/y.
+func divmod64(a Uint128, y uint64) (quo, rem Uint128) {
+	qH := a.H / y
+	qL, r := bits.Div64(a.H%y, a.L, y)
+	return Uint128{qH, qL}, Uint128{0, r}
+}
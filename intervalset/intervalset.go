@@ -0,0 +1,263 @@
+// Package intervalset provides a set of int128.Uint128 values
+// represented by disjoint closed intervals.
+package intervalset
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/shogo82148/int128"
+)
+
+var (
+	zero = int128.Uint128{}
+	one  = int128.Uint128{H: 0, L: 1}
+	max  = int128.Uint128{H: 0xffff_ffff_ffff_ffff, L: 0xffff_ffff_ffff_ffff}
+)
+
+// Interval is the closed interval [First, Last].
+type Interval struct {
+	First, Last int128.Uint128
+}
+
+// Contains reports whether v is in iv.
+func (iv Interval) Contains(v int128.Uint128) bool {
+	return iv.First.Cmp(v) <= 0 && v.Cmp(iv.Last) <= 0
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+// iv is encoded as a JSON array of two numbers [First, Last].
+func (iv Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int128.Uint128{iv.First, iv.Last})
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+func (iv *Interval) UnmarshalJSON(data []byte) error {
+	var v [2]json.Number
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	first, err := parseUint128(v[0])
+	if err != nil {
+		return err
+	}
+	last, err := parseUint128(v[1])
+	if err != nil {
+		return err
+	}
+	if first.Cmp(last) > 0 {
+		return errors.New("intervalset: the first value of the interval is greater than the last")
+	}
+	iv.First, iv.Last = first, last
+	return nil
+}
+
+func parseUint128(n json.Number) (int128.Uint128, error) {
+	var v int128.Uint128
+	if err := v.UnmarshalText([]byte(n)); err != nil {
+		return int128.Uint128{}, errors.New("intervalset: invalid value " + string(n))
+	}
+	return v, nil
+}
+
+// Set is a set of int128.Uint128 values.
+// The zero value is an empty set.
+//
+// Set stores the values as a sorted slice of disjoint intervals,
+// and merges the adjacent intervals.
+// It can hold the full range [0, 2**128-1].
+type Set struct {
+	// ivs is sorted, and ivs[i].Last+1 < ivs[i+1].First for all i.
+	ivs []Interval
+}
+
+// Intervals returns the intervals of s in ascending order.
+func (s *Set) Intervals() []Interval {
+	return append([]Interval(nil), s.ivs...)
+}
+
+// Len returns the number of the intervals of s.
+func (s *Set) Len() int {
+	return len(s.ivs)
+}
+
+// Insert adds the values in [first, last] to s.
+// It panics if first > last.
+func (s *Set) Insert(first, last int128.Uint128) {
+	checkInterval(first, last)
+
+	// find the intervals that overlap or are adjacent to [first, last].
+	lo, hi := first, last
+	if lo != zero {
+		lo = lo.Sub(one)
+	}
+	if hi != max {
+		hi = hi.Add(one)
+	}
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Last.Cmp(lo) >= 0 })
+	j := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].First.Cmp(hi) > 0 })
+
+	// merge them.
+	if i < j {
+		if s.ivs[i].First.Cmp(first) < 0 {
+			first = s.ivs[i].First
+		}
+		if s.ivs[j-1].Last.Cmp(last) > 0 {
+			last = s.ivs[j-1].Last
+		}
+	}
+	s.replace(i, j, Interval{first, last})
+}
+
+// Remove removes the values in [first, last] from s.
+// It panics if first > last.
+func (s *Set) Remove(first, last int128.Uint128) {
+	checkInterval(first, last)
+
+	// find the intervals that overlap [first, last].
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Last.Cmp(first) >= 0 })
+	j := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].First.Cmp(last) > 0 })
+	if i >= j {
+		return
+	}
+
+	// keep the parts out of [first, last].
+	var rest [2]Interval
+	n := 0
+	if s.ivs[i].First.Cmp(first) < 0 {
+		rest[n] = Interval{s.ivs[i].First, first.Sub(one)}
+		n++
+	}
+	if s.ivs[j-1].Last.Cmp(last) > 0 {
+		rest[n] = Interval{last.Add(one), s.ivs[j-1].Last}
+		n++
+	}
+	s.replace(i, j, rest[:n]...)
+}
+
+// replace replaces s.ivs[i:j] with ivs.
+func (s *Set) replace(i, j int, ivs ...Interval) {
+	if j-i == len(ivs) {
+		copy(s.ivs[i:], ivs)
+		return
+	}
+	tail := len(s.ivs) - j
+	newLen := i + len(ivs) + tail
+	if newLen > cap(s.ivs) {
+		n := make([]Interval, newLen, newLen*2)
+		copy(n, s.ivs[:i])
+		copy(n[i+len(ivs):], s.ivs[j:])
+		s.ivs = n
+	} else {
+		old := s.ivs
+		s.ivs = s.ivs[:newLen]
+		copy(s.ivs[i+len(ivs):], old[j:j+tail])
+	}
+	copy(s.ivs[i:], ivs)
+}
+
+func checkInterval(first, last int128.Uint128) {
+	if first.Cmp(last) > 0 {
+		panic("intervalset: invalid interval")
+	}
+}
+
+// Contains reports whether v is in s.
+func (s *Set) Contains(v int128.Uint128) bool {
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Last.Cmp(v) >= 0 })
+	return i < len(s.ivs) && s.ivs[i].First.Cmp(v) <= 0
+}
+
+// Allocate finds the lowest block of size consecutive values in s,
+// removes it from s, and returns its first value.
+// ok is false if s has no such block.
+// It panics if size is zero.
+func (s *Set) Allocate(size int128.Uint128) (first int128.Uint128, ok bool) {
+	if size == zero {
+		panic("intervalset: zero size allocation")
+	}
+	n := size.Sub(one)
+	for _, iv := range s.ivs {
+		if iv.Last.Sub(iv.First).Cmp(n) >= 0 {
+			first = iv.First
+			s.Remove(first, first.Add(n))
+			return first, true
+		}
+	}
+	return zero, false
+}
+
+// Complement returns a new set of the values that are not in s.
+func (s *Set) Complement() *Set {
+	ret := &Set{ivs: make([]Interval, 0, len(s.ivs)+1)}
+	next := zero
+	for _, iv := range s.ivs {
+		if next.Cmp(iv.First) < 0 {
+			ret.ivs = append(ret.ivs, Interval{next, iv.First.Sub(one)})
+		}
+		if iv.Last == max {
+			return ret
+		}
+		next = iv.Last.Add(one)
+	}
+	ret.ivs = append(ret.ivs, Interval{next, max})
+	return ret
+}
+
+// Union returns a new set of the values that are in s or t.
+func (s *Set) Union(t *Set) *Set {
+	ret := &Set{ivs: append([]Interval(nil), s.ivs...)}
+	for _, iv := range t.ivs {
+		ret.Insert(iv.First, iv.Last)
+	}
+	return ret
+}
+
+// Intersect returns a new set of the values that are in both s and t.
+func (s *Set) Intersect(t *Set) *Set {
+	ret := &Set{}
+	i, j := 0, 0
+	for i < len(s.ivs) && j < len(t.ivs) {
+		a, b := s.ivs[i], t.ivs[j]
+		first, last := a.First, a.Last
+		if b.First.Cmp(first) > 0 {
+			first = b.First
+		}
+		if b.Last.Cmp(last) < 0 {
+			last = b.Last
+		}
+		if first.Cmp(last) <= 0 {
+			ret.ivs = append(ret.ivs, Interval{first, last})
+		}
+		if a.Last.Cmp(b.Last) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return ret
+}
+
+// MarshalJSON implements [encoding/json.Marshaler].
+// s is encoded as a JSON array of the intervals, e.g. [[1,2],[5,10]].
+func (s Set) MarshalJSON() ([]byte, error) {
+	if s.ivs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.ivs)
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+// The intervals may be unsorted and overlapping.
+func (s *Set) UnmarshalJSON(data []byte) error {
+	var ivs []Interval
+	if err := json.Unmarshal(data, &ivs); err != nil {
+		return err
+	}
+	s.ivs = nil
+	for _, iv := range ivs {
+		s.Insert(iv.First, iv.Last)
+	}
+	return nil
+}
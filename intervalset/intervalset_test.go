@@ -0,0 +1,259 @@
+package intervalset
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/shogo82148/int128"
+)
+
+func u(v uint64) int128.Uint128 {
+	return int128.Uint128{H: 0, L: v}
+}
+
+func checkInvariant(t *testing.T, s *Set) {
+	t.Helper()
+	for i, iv := range s.ivs {
+		if iv.First.Cmp(iv.Last) > 0 {
+			t.Fatalf("%d: invalid interval %v", i, iv)
+		}
+		if i > 0 && s.ivs[i-1].Last.Add(one).Cmp(iv.First) >= 0 {
+			t.Fatalf("%d: the intervals %v and %v must be merged", i, s.ivs[i-1], iv)
+		}
+	}
+}
+
+func TestSet_InsertRemove(t *testing.T) {
+	var s Set
+	s.Insert(u(10), u(20))
+	s.Insert(u(30), u(40))
+	s.Insert(u(21), u(29)) // adjacent to both
+	if want := []Interval{{u(10), u(40)}}; !reflect.DeepEqual(s.Intervals(), want) {
+		t.Errorf("want %v, got %v", want, s.Intervals())
+	}
+
+	s.Remove(u(15), u(35))
+	if want := []Interval{{u(10), u(14)}, {u(36), u(40)}}; !reflect.DeepEqual(s.Intervals(), want) {
+		t.Errorf("want %v, got %v", want, s.Intervals())
+	}
+	if s.Contains(u(15)) || !s.Contains(u(14)) || !s.Contains(u(36)) || s.Contains(u(41)) {
+		t.Errorf("unexpected Contains result: %v", s.Intervals())
+	}
+}
+
+func TestSet_FullRange(t *testing.T) {
+	var s Set
+	s.Insert(zero, max)
+	if !s.Contains(zero) || !s.Contains(max) {
+		t.Error("the full range must contain 0 and max")
+	}
+	if c := s.Complement(); c.Len() != 0 {
+		t.Errorf("the complement of the full range should be empty, but %v", c.Intervals())
+	}
+
+	s.Remove(max, max)
+	s.Remove(zero, zero)
+	if want := []Interval{{one, max.Sub(one)}}; !reflect.DeepEqual(s.Intervals(), want) {
+		t.Errorf("want %v, got %v", want, s.Intervals())
+	}
+	if want := []Interval{{zero, zero}, {max, max}}; !reflect.DeepEqual(s.Complement().Intervals(), want) {
+		t.Errorf("want %v, got %v", want, s.Complement().Intervals())
+	}
+
+	s.Insert(max, max)
+	s.Insert(zero, zero)
+	if want := []Interval{{zero, max}}; !reflect.DeepEqual(s.Intervals(), want) {
+		t.Errorf("want %v, got %v", want, s.Intervals())
+	}
+
+	var empty Set
+	if want := []Interval{{zero, max}}; !reflect.DeepEqual(empty.Complement().Intervals(), want) {
+		t.Errorf("want %v, got %v", want, empty.Complement().Intervals())
+	}
+}
+
+func TestSet_Allocate(t *testing.T) {
+	var s Set
+	s.Insert(u(0), u(3))
+	s.Insert(u(10), u(19))
+
+	first, ok := s.Allocate(u(5))
+	if !ok || first != u(10) {
+		t.Errorf("want 10, got %v, %v", first, ok)
+	}
+	first, ok = s.Allocate(u(4))
+	if !ok || first != u(0) {
+		t.Errorf("want 0, got %v, %v", first, ok)
+	}
+	if _, ok := s.Allocate(u(6)); ok {
+		t.Error("want no space, but allocated")
+	}
+	if want := []Interval{{u(15), u(19)}}; !reflect.DeepEqual(s.Intervals(), want) {
+		t.Errorf("want %v, got %v", want, s.Intervals())
+	}
+
+	// the full range can serve the largest block.
+	var full Set
+	full.Insert(zero, max)
+	first, ok = full.Allocate(max)
+	if !ok || first != zero {
+		t.Errorf("want 0, got %v, %v", first, ok)
+	}
+	if want := []Interval{{max, max}}; !reflect.DeepEqual(full.Intervals(), want) {
+		t.Errorf("want %v, got %v", want, full.Intervals())
+	}
+}
+
+// TestSet_Random compares Set with a bitmap of 256 values
+// placed at the both ends of the Uint128 space.
+func TestSet_Random(t *testing.T) {
+	const width = 256
+	for _, base := range []int128.Uint128{zero, max.Sub(u(width - 1))} {
+		r := rand.New(rand.NewSource(1))
+		var s, other Set
+		var bitmap, otherBitmap [width]bool
+		for i := 0; i < 2000; i++ {
+			a, b := r.Intn(width), r.Intn(width)
+			if a > b {
+				a, b = b, a
+			}
+			first, last := base.Add(u(uint64(a))), base.Add(u(uint64(b)))
+			switch r.Intn(4) {
+			case 0, 1:
+				s.Insert(first, last)
+				for k := a; k <= b; k++ {
+					bitmap[k] = true
+				}
+			case 2:
+				s.Remove(first, last)
+				for k := a; k <= b; k++ {
+					bitmap[k] = false
+				}
+			case 3:
+				other.Insert(first, last)
+				for k := a; k <= b; k++ {
+					otherBitmap[k] = true
+				}
+			}
+			checkInvariant(t, &s)
+
+			if i%100 != 0 {
+				continue
+			}
+			complement := s.Complement()
+			union := s.Union(&other)
+			intersect := s.Intersect(&other)
+			checkInvariant(t, complement)
+			checkInvariant(t, union)
+			checkInvariant(t, intersect)
+			for k := 0; k < width; k++ {
+				v := base.Add(u(uint64(k)))
+				if s.Contains(v) != bitmap[k] {
+					t.Fatalf("Contains(%d) should %v", v, bitmap[k])
+				}
+				if complement.Contains(v) == bitmap[k] {
+					t.Fatalf("Complement().Contains(%d) should %v", v, !bitmap[k])
+				}
+				if union.Contains(v) != (bitmap[k] || otherBitmap[k]) {
+					t.Fatalf("Union().Contains(%d) should %v", v, bitmap[k] || otherBitmap[k])
+				}
+				if intersect.Contains(v) != (bitmap[k] && otherBitmap[k]) {
+					t.Fatalf("Intersect().Contains(%d) should %v", v, bitmap[k] && otherBitmap[k])
+				}
+			}
+			if c := complement.Complement(); !reflect.DeepEqual(c.Intervals(), s.Intervals()) {
+				t.Fatalf("Complement().Complement() should %v, but %v", s.Intervals(), c.Intervals())
+			}
+		}
+	}
+}
+
+func TestSet_JSON(t *testing.T) {
+	var s Set
+	s.Insert(u(1), u(2))
+	s.Insert(zero.Sub(u(10)), max)
+	data, err := json.Marshal(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[[1,2],[340282366920938463463374607431768211446,340282366920938463463374607431768211455]]`
+	if string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+
+	var got Set
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Intervals(), s.Intervals()) {
+		t.Errorf("want %v, got %v", s.Intervals(), got.Intervals())
+	}
+
+	// the empty set
+	data, err = json.Marshal(&Set{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("want [], got %s", data)
+	}
+
+	// a Set stored by value, such as a struct field or a value in an interface{}.
+	data, err = json.Marshal(struct{ S Set }{s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"S":` + want + `}`; string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+	data, err = json.Marshal([]interface{}{s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[` + want + `]`; string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+
+	// unsorted and overlapping intervals are normalized.
+	if err := json.Unmarshal([]byte(`[[5,10],[1,6]]`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := []Interval{{u(1), u(10)}}; !reflect.DeepEqual(got.Intervals(), want) {
+		t.Errorf("want %v, got %v", want, got.Intervals())
+	}
+
+	invalid := []string{
+		`[[2,1]]`,
+		`[[-1,1]]`,
+		`[[0,340282366920938463463374607431768211456]]`,
+		`[[1.5,2]]`,
+		`{}`,
+	}
+	for i, data := range invalid {
+		if err := json.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("%d: %s: want error, but not", i, data)
+		}
+	}
+}
+
+func BenchmarkSet_Insert(b *testing.B) {
+	var s Set
+	for i := 0; i < b.N; i++ {
+		v := u(uint64(i) * 3)
+		s.Insert(v, v.Add(one))
+	}
+}
+
+func BenchmarkSet_Contains(b *testing.B) {
+	var s Set
+	for i := 0; i < 1000; i++ {
+		v := u(uint64(i) * 3)
+		s.Insert(v, v.Add(one))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(u(uint64(i % 3000)))
+	}
+}
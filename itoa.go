@@ -0,0 +1,110 @@
+package int128
+
+import "math/bits"
+
+// maxDecimalLen is the length of the decimal representation of the max value of Uint128.
+const maxDecimalLen = 39
+
+// AppendDecimal appends the decimal representation of a to dst and returns the extended buffer.
+// It is equivalent to a.Append(dst, 10), and doesn't allocate if dst has enough capacity.
+func (a Uint128) AppendDecimal(dst []byte) []byte {
+	if a.H == 0 && a.L < nSmalls {
+		return append(dst, small(int(a.L))...)
+	}
+	var buf [maxDecimalLen]byte
+	i := putDecimal(buf[:], a.H, a.L)
+	return append(dst, buf[i:]...)
+}
+
+// AppendDecimal appends the decimal representation of a to dst and returns the extended buffer.
+// It is equivalent to a.Append(dst, 10), and doesn't allocate if dst has enough capacity.
+func (a Int128) AppendDecimal(dst []byte) []byte {
+	if a.H == 0 && a.L < nSmalls {
+		return append(dst, small(int(a.L))...)
+	}
+	var buf [maxDecimalLen + 1]byte // +1 is for the sign
+	abs := a.AbsUint128()
+	i := putDecimal(buf[:], abs.H, abs.L)
+	if a.IsNeg() {
+		i--
+		buf[i] = '-'
+	}
+	return append(dst, buf[i:]...)
+}
+
+// putDecimal writes the decimal representation of (h, l) to the end of buf,
+// and returns the index of the first digit.
+// buf must be at least maxDecimalLen bytes long.
+//
+// The value is split into at most three 19-digit chunks,
+// and each chunk is written by putChunk without any branches.
+func putDecimal(buf []byte, h, l uint64) int {
+	i := len(buf)
+	if h != 0 {
+		// (h, l) = q*1e19 + r
+		// q < 2**128 / 1e19 < 2**65, so it is (q0, q1) in two words.
+		q0 := h / 1e19
+		q1, r := bits.Div64(h%1e19, l, 1e19)
+		i -= 19
+		putChunk(buf[i:], r)
+
+		if q0 == 0 && q1 < 1e19 {
+			l = q1
+		} else {
+			// q = l*1e19 + r, and l < 2**128 / 1e38 < 4.
+			l, r = bits.Div64(q0, q1, 1e19)
+			i -= 19
+			putChunk(buf[i:], r)
+		}
+	}
+
+	// the compiler optimizes the divisions by the constants into multiply+shift.
+	for l >= 1e8 {
+		i -= 8
+		put8(buf[i:], uint32(l%1e8))
+		l /= 1e8
+	}
+	v := uint32(l)
+	for v >= 100 {
+		is := (v % 100) * 2
+		v /= 100
+		i -= 2
+		buf[i+1] = smallsString[is+1]
+		buf[i+0] = smallsString[is+0]
+	}
+	if v >= 10 {
+		is := v * 2
+		i -= 2
+		buf[i+1] = smallsString[is+1]
+		buf[i+0] = smallsString[is+0]
+		return i
+	}
+	i--
+	buf[i] = digits[v]
+	return i
+}
+
+// putChunk writes v < 1e19 as the 19-digit decimal number with leading zeros to buf[:19].
+func putChunk(buf []byte, v uint64) {
+	_ = buf[18] // bounds check hint to compiler
+	top := uint32(v / 1e16)
+	v %= 1e16
+	buf[0] = byte('0' + top/100)
+	is := (top % 100) * 2
+	buf[1] = smallsString[is+0]
+	buf[2] = smallsString[is+1]
+	put8(buf[3:], uint32(v/1e8))
+	put8(buf[11:], uint32(v%1e8))
+}
+
+// put8 writes v < 1e8 as the 8-digit decimal number with leading zeros to buf[:8].
+func put8(buf []byte, v uint32) {
+	_ = buf[7] // bounds check hint to compiler
+	hi, lo := v/10000, v%10000
+	i0, i1 := (hi/100)*2, (hi%100)*2
+	i2, i3 := (lo/100)*2, (lo%100)*2
+	buf[0], buf[1] = smallsString[i0], smallsString[i0+1]
+	buf[2], buf[3] = smallsString[i1], smallsString[i1+1]
+	buf[4], buf[5] = smallsString[i2], smallsString[i2+1]
+	buf[6], buf[7] = smallsString[i3], smallsString[i3+1]
+}
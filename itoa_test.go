@@ -0,0 +1,165 @@
+package int128
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"testing/quick"
+)
+
+func TestUint128_AppendDecimal(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		want string
+	}{
+		{Uint128{0, 0}, "0"},
+		{Uint128{0, 99}, "99"},
+		{Uint128{0, 100}, "100"},
+		{Uint128{0, 99999999}, "99999999"},
+		{Uint128{0, 100000000}, "100000000"},
+		{Uint128{0, 9999999999999999999}, "9999999999999999999"},
+		{Uint128{0, 10000000000000000000}, "10000000000000000000"},
+		{Uint128{0, math.MaxUint64}, "18446744073709551615"},
+		{Uint128{1, 0}, "18446744073709551616"},
+		// 1e19 * 1e19 - 1
+		{Uint128{0x4b3b4ca85a86c47a, 0x098a_2240_0000_0000}.Sub(Uint128{0, 1}), "99999999999999999999999999999999999999"},
+		// 1e38
+		{Uint128{0x4b3b4ca85a86c47a, 0x098a_2240_0000_0000}, "100000000000000000000000000000000000000"},
+		{Uint128{math.MaxUint64, math.MaxUint64}, "340282366920938463463374607431768211455"},
+	}
+
+	for i, tc := range testCases {
+		got := string(tc.a.AppendDecimal([]byte("prefix:")))
+		if got != "prefix:"+tc.want {
+			t.Errorf("%d: %#v.AppendDecimal should %q, but %q", i, tc.a, "prefix:"+tc.want, got)
+		}
+	}
+}
+
+func TestUint128_AppendDecimalQuick(t *testing.T) {
+	f := func(a Uint128, shift uint8) string {
+		// test the values of various lengths.
+		a = a.Rsh(uint(shift % 128))
+		return string(a.AppendDecimal(nil))
+	}
+	g := func(a Uint128, shift uint8) string {
+		a = a.Rsh(uint(shift % 128))
+		return uint128ToBig(nil, a).Text(10)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_AppendDecimalQuick(t *testing.T) {
+	f := func(a Int128, shift uint8) string {
+		// test the values of various lengths.
+		a = a.Rsh(uint(shift % 128))
+		return string(a.AppendDecimal(nil))
+	}
+	g := func(a Int128, shift uint8) string {
+		a = a.Rsh(uint(shift % 128))
+		return int128ToBig(nil, a).Text(10)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_AppendDecimal(t *testing.T) {
+	testCases := []struct {
+		a    Int128
+		want string
+	}{
+		{Int128{0, 0}, "0"},
+		{Int128{-1, math.MaxUint64}, "-1"},
+		{Int128{-1, 0}, "-18446744073709551616"},
+		{Int128{math.MaxInt64, math.MaxUint64}, "170141183460469231731687303715884105727"},
+		{Int128{math.MinInt64, 0}, "-170141183460469231731687303715884105728"},
+	}
+
+	for i, tc := range testCases {
+		got := string(tc.a.AppendDecimal(nil))
+		if got != tc.want {
+			t.Errorf("%d: %#v.AppendDecimal should %q, but %q", i, tc.a, tc.want, got)
+		}
+	}
+}
+
+func TestUint128_AppendDecimalAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	a := Uint128{math.MaxUint64, math.MaxUint64}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = a.AppendDecimal(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendDecimal should not allocate, but %f allocs", allocs)
+	}
+}
+
+func BenchmarkUint128_AppendDecimal(b *testing.B) {
+	buf := make([]byte, 0, 64)
+
+	// a 19-digit value is one chunk.
+	b.Run("19 digits", func(b *testing.B) {
+		v := Uint128{0, 9999999999999999999}
+		for i := 0; i < b.N; i++ {
+			buf = v.AppendDecimal(buf[:0])
+		}
+	})
+
+	b.Run("strconv.AppendUint(19 digits)", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = strconv.AppendUint(buf[:0], 9999999999999999999, 10)
+		}
+	})
+
+	b.Run("the max value of uint64", func(b *testing.B) {
+		v := Uint128{0, math.MaxUint64}
+		for i := 0; i < b.N; i++ {
+			buf = v.AppendDecimal(buf[:0])
+		}
+	})
+
+	b.Run("strconv.AppendUint(the max value of uint64)", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = strconv.AppendUint(buf[:0], math.MaxUint64, 10)
+		}
+	})
+
+	// the max value of Uint128 is three chunks.
+	b.Run("the max value of Uint128", func(b *testing.B) {
+		v := Uint128{math.MaxUint64, math.MaxUint64}
+		for i := 0; i < b.N; i++ {
+			buf = v.AppendDecimal(buf[:0])
+		}
+	})
+
+	b.Run("strconv.AppendUint(three chunks)", func(b *testing.B) {
+		// 34 028236692093846346 3374607431768211455
+		for i := 0; i < b.N; i++ {
+			buf = strconv.AppendUint(buf[:0], 34, 10)
+			buf = strconv.AppendUint(buf, 282366920938463463, 10)
+			buf = strconv.AppendUint(buf, 3374607431768211455, 10)
+		}
+	})
+
+	b.Run("Append(the max value of Uint128, 10)", func(b *testing.B) {
+		v := Uint128{math.MaxUint64, math.MaxUint64}
+		for i := 0; i < b.N; i++ {
+			buf = v.Append(buf[:0], 10)
+		}
+	})
+}
+
+func BenchmarkInt128_AppendDecimal(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	v := Int128{math.MinInt64, 0}
+	for i := 0; i < b.N; i++ {
+		buf = v.AppendDecimal(buf[:0])
+	}
+}
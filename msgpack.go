@@ -0,0 +1,248 @@
+package int128
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The MessagePack extension types for the integers that don't fit in 64 bits.
+// The payload is the 16-byte big-endian representation of the value,
+// and it is encoded in the fixext 16 format.
+//
+// The values are encoded as follows:
+//
+//	value                          format                        payload
+//	-----------------------------  ----------------------------  ----------------------------
+//	0 <= v < 2^64                  positive fixint, uint 8 - 64  -
+//	-2^63 <= v < 0                 negative fixint, int 8 - 64   -
+//	Uint128 v >= 2^64              fixext 16, type 16            unsigned, big-endian
+//	Int128 v < -2^63 or v >= 2^64  fixext 16, type 17            two's complement, big-endian
+//
+// The decoders accept any integer format whose value is in range,
+// and both of the extension types.
+//
+// The following hooks of msgpack for Python (https://pypi.org/project/msgpack/)
+// are compatible with them:
+//
+//	def default(obj):
+//	    # msgpack calls default for the integers that don't fit in 64 bits.
+//	    if isinstance(obj, int):
+//	        if 0 <= obj < 1 << 128:
+//	            return msgpack.ExtType(16, obj.to_bytes(16, "big"))
+//	        if -(1 << 127) <= obj < 0:
+//	            return msgpack.ExtType(17, obj.to_bytes(16, "big", signed=True))
+//	    raise TypeError(f"cannot serialize {obj!r}")
+//
+//	def ext_hook(code, data):
+//	    if code == 16:
+//	        return int.from_bytes(data, "big")
+//	    if code == 17:
+//	        return int.from_bytes(data, "big", signed=True)
+//	    return msgpack.ExtType(code, data)
+//
+//	data = msgpack.packb(1 << 100, default=default)
+//	value = msgpack.unpackb(data, ext_hook=ext_hook)
+const (
+	MsgpackExtUint128 = 16
+	MsgpackExtInt128  = 17
+)
+
+var (
+	errMsgpackSyntax = errors.New("int128: invalid MessagePack data")
+	errMsgpackType   = errors.New("int128: MessagePack object is not an integer")
+	errMsgpackRange  = errors.New("int128: MessagePack integer out of range")
+)
+
+// AppendMsgpack appends the MessagePack encoding of a to dst and returns the extended buffer.
+// a is encoded as an integer if it fits in 64 bits,
+// and as the extension type MsgpackExtUint128 otherwise.
+func (a Uint128) AppendMsgpack(dst []byte) []byte {
+	if a.H == 0 {
+		return appendMsgpackUint(dst, a.L)
+	}
+	return appendMsgpackExt(dst, MsgpackExtUint128, a)
+}
+
+// DecodeMsgpack decodes a MessagePack integer from the beginning of data into a,
+// and returns the rest of data.
+func (a *Uint128) DecodeMsgpack(data []byte) ([]byte, error) {
+	v, rest, err := parseMsgpack(data)
+	if err != nil {
+		return data, err
+	}
+	if v.neg {
+		return data, errMsgpackRange
+	}
+	*a = v.v
+	return rest, nil
+}
+
+// AppendMsgpack appends the MessagePack encoding of a to dst and returns the extended buffer.
+// a is encoded as an integer if it fits in 64 bits,
+// and as the extension type MsgpackExtInt128 otherwise.
+func (a Int128) AppendMsgpack(dst []byte) []byte {
+	switch {
+	case a.H == 0:
+		return appendMsgpackUint(dst, a.L)
+	case a.H == -1 && int64(a.L) < 0:
+		return appendMsgpackInt(dst, int64(a.L))
+	}
+	return appendMsgpackExt(dst, MsgpackExtInt128, a.Uint128())
+}
+
+// DecodeMsgpack decodes a MessagePack integer from the beginning of data into a,
+// and returns the rest of data.
+func (a *Int128) DecodeMsgpack(data []byte) ([]byte, error) {
+	v, rest, err := parseMsgpack(data)
+	if err != nil {
+		return data, err
+	}
+	if v.neg != (v.v.H >= 1<<63) {
+		return data, errMsgpackRange
+	}
+	*a = v.v.Int128()
+	return rest, nil
+}
+
+func appendMsgpackUint(dst []byte, v uint64) []byte {
+	switch {
+	case v < 0x80:
+		return append(dst, byte(v))
+	case v <= 0xff:
+		return append(dst, 0xcc, byte(v))
+	case v <= 0xffff:
+		return append(dst, 0xcd, byte(v>>8), byte(v))
+	case v <= 0xffff_ffff:
+		return append(dst, 0xce, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return append(dst, 0xcf,
+			byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+			byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+}
+
+// appendMsgpackInt appends the negative integer v.
+func appendMsgpackInt(dst []byte, v int64) []byte {
+	switch {
+	case v >= -32:
+		return append(dst, byte(v))
+	case v >= -0x80:
+		return append(dst, 0xd0, byte(v))
+	case v >= -0x8000:
+		return append(dst, 0xd1, byte(v>>8), byte(v))
+	case v >= -0x8000_0000:
+		return append(dst, 0xd2, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return append(dst, 0xd3,
+			byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+			byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+}
+
+func appendMsgpackExt(dst []byte, typ byte, v Uint128) []byte {
+	dst = append(dst, 0xd8, typ)
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], v.H)
+	binary.BigEndian.PutUint64(buf[8:], v.L)
+	return append(dst, buf[:]...)
+}
+
+// msgpackInt is a decoded MessagePack integer.
+// v is the 128-bit two's complement representation,
+// and neg reports whether the integer is negative.
+type msgpackInt struct {
+	v   Uint128
+	neg bool
+}
+
+func parseMsgpack(data []byte) (msgpackInt, []byte, error) {
+	if len(data) == 0 {
+		return msgpackInt{}, nil, errMsgpackSyntax
+	}
+
+	b := data[0]
+	switch {
+	case b < 0x80:
+		// positive fixint
+		return msgpackInt{v: Uint128{0, uint64(b)}}, data[1:], nil
+	case b >= 0xe0:
+		// negative fixint
+		return msgpackSigned(int64(int8(b))), data[1:], nil
+	}
+
+	var n int
+	switch b {
+	case 0xcc, 0xd0:
+		n = 1
+	case 0xcd, 0xd1:
+		n = 2
+	case 0xce, 0xd2:
+		n = 4
+	case 0xcf, 0xd3:
+		n = 8
+	case 0xd8, 0xc7, 0xc8, 0xc9:
+		return parseMsgpackExt(data)
+	default:
+		return msgpackInt{}, nil, errMsgpackType
+	}
+	if len(data) < 1+n {
+		return msgpackInt{}, nil, errMsgpackSyntax
+	}
+	var u uint64
+	for _, c := range data[1 : 1+n] {
+		u = u<<8 | uint64(c)
+	}
+	rest := data[1+n:]
+
+	if b <= 0xcf {
+		// uint 8 - 64
+		return msgpackInt{v: Uint128{0, u}}, rest, nil
+	}
+	// int 8 - 64: sign-extend the value
+	shift := 64 - 8*uint(n)
+	return msgpackSigned(int64(u<<shift) >> shift), rest, nil
+}
+
+func msgpackSigned(v int64) msgpackInt {
+	return msgpackInt{v: Int128{v >> 63, uint64(v)}.Uint128(), neg: v < 0}
+}
+
+// parseMsgpackExt parses the extension types MsgpackExtUint128 and MsgpackExtInt128.
+// Their payloads are usually encoded in fixext 16,
+// but ext 8, 16 and 32 with the length 16 are also accepted.
+func parseMsgpackExt(data []byte) (msgpackInt, []byte, error) {
+	var head int
+	var size uint64
+	switch data[0] {
+	case 0xd8:
+		head, size = 1, 16
+	case 0xc7:
+		head = 2
+	case 0xc8:
+		head = 3
+	case 0xc9:
+		head = 5
+	}
+	if len(data) < head+1 {
+		return msgpackInt{}, nil, errMsgpackSyntax
+	}
+	if data[0] != 0xd8 {
+		for _, c := range data[1:head] {
+			size = size<<8 | uint64(c)
+		}
+	}
+	typ := data[head]
+	if typ != MsgpackExtUint128 && typ != MsgpackExtInt128 {
+		return msgpackInt{}, nil, errMsgpackType
+	}
+	if size != 16 {
+		return msgpackInt{}, nil, errMsgpackSyntax
+	}
+
+	payload := data[head+1:]
+	if len(payload) < 16 {
+		return msgpackInt{}, nil, errMsgpackSyntax
+	}
+	v := Uint128{binary.BigEndian.Uint64(payload[:8]), binary.BigEndian.Uint64(payload[8:16])}
+	return msgpackInt{v: v, neg: typ == MsgpackExtInt128 && v.H >= 1<<63}, payload[16:], nil
+}
@@ -0,0 +1,249 @@
+package int128
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+func TestUint128_AppendMsgpack(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		want string
+	}{
+		{Uint128{0, 0}, "00"},
+		{Uint128{0, 0x7f}, "7f"},
+		{Uint128{0, 0x80}, "cc80"},
+		{Uint128{0, 0xff}, "ccff"},
+		{Uint128{0, 0x100}, "cd0100"},
+		{Uint128{0, 0xffff}, "cdffff"},
+		{Uint128{0, 0x10000}, "ce00010000"},
+		{Uint128{0, 0xffff_ffff}, "ceffffffff"},
+		{Uint128{0, 0x1_0000_0000}, "cf0000000100000000"},
+		{Uint128{0, math.MaxUint64}, "cfffffffffffffffff"},
+		{Uint128{1, 0}, "d810" + "00000000000000010000000000000000"},
+		{Uint128{math.MaxUint64, math.MaxUint64}, "d810" + "ffffffffffffffffffffffffffffffff"},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.AppendMsgpack([]byte{0x90})
+		if hex.EncodeToString(got) != "90"+tc.want {
+			t.Errorf("%d: %#v should %s, but %x", i, tc.a, tc.want, got[1:])
+		}
+
+		data, _ := hex.DecodeString(tc.want + "c0")
+		var a Uint128
+		rest, err := a.DecodeMsgpack(data)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if a != tc.a {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.want, tc.a, a)
+		}
+		if string(rest) != "\xc0" {
+			t.Errorf("%d: unexpected rest: %x", i, rest)
+		}
+	}
+}
+
+func TestInt128_AppendMsgpack(t *testing.T) {
+	testCases := []struct {
+		a    Int128
+		want string
+	}{
+		{Int128{0, 0}, "00"},
+		{Int128{0, 0x7f}, "7f"},
+		{Int128{0, 0x80}, "cc80"},
+		{Int128{0, math.MaxUint64}, "cfffffffffffffffff"},
+		{Int128{-1, math.MaxUint64}, "ff"},
+		{Int128{-1, -32 & math.MaxUint64}, "e0"},
+		{Int128{-1, -33 & math.MaxUint64}, "d0df"},
+		{Int128{-1, -0x80 & math.MaxUint64}, "d080"},
+		{Int128{-1, -0x81 & math.MaxUint64}, "d1ff7f"},
+		{Int128{-1, -0x8000 & math.MaxUint64}, "d18000"},
+		{Int128{-1, -0x8001 & math.MaxUint64}, "d2ffff7fff"},
+		{Int128{-1, -0x8000_0000 & math.MaxUint64}, "d280000000"},
+		{Int128{-1, -0x8000_0001 & math.MaxUint64}, "d3ffffffff7fffffff"},
+		{Int128{-1, 1 << 63}, "d38000000000000000"},
+		{Int128{-1, 1<<63 - 1}, "d811" + "ffffffffffffffff7fffffffffffffff"},
+		{Int128{1, 0}, "d811" + "00000000000000010000000000000000"},
+		{Int128{math.MaxInt64, math.MaxUint64}, "d811" + "7fffffffffffffffffffffffffffffff"},
+		{Int128{math.MinInt64, 0}, "d811" + "80000000000000000000000000000000"},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.AppendMsgpack([]byte{0x90})
+		if hex.EncodeToString(got) != "90"+tc.want {
+			t.Errorf("%d: %#v should %s, but %x", i, tc.a, tc.want, got[1:])
+		}
+
+		data, _ := hex.DecodeString(tc.want + "c0")
+		var a Int128
+		rest, err := a.DecodeMsgpack(data)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if a != tc.a {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.want, tc.a, a)
+		}
+		if string(rest) != "\xc0" {
+			t.Errorf("%d: unexpected rest: %x", i, rest)
+		}
+	}
+}
+
+func TestDecodeMsgpack(t *testing.T) {
+	// non-minimal encodings and the other integer formats are accepted.
+	testCases := []struct {
+		data string
+		want Int128
+	}{
+		{"cc00", Int128{0, 0}},
+		{"cf0000000000000001", Int128{0, 1}},
+		{"d001", Int128{0, 1}},
+		{"d3ffffffffffffffff", Int128{-1, math.MaxUint64}},
+		{"d07f", Int128{0, 0x7f}},
+		{"d17fff", Int128{0, 0x7fff}},
+		{"d27fffffff", Int128{0, 0x7fff_ffff}},
+		{"d810" + "00000000000000000000000000000001", Int128{0, 1}},
+		{"d811" + "ffffffffffffffffffffffffffffffff", Int128{-1, math.MaxUint64}},
+		{"c71011" + "ffffffffffffffffffffffffffffffff", Int128{-1, math.MaxUint64}},
+		{"c8001010" + "00000000000000010000000000000000", Int128{1, 0}},
+		{"c90000001010" + "00000000000000010000000000000000", Int128{1, 0}},
+	}
+
+	for i, tc := range testCases {
+		data, err := hex.DecodeString(tc.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var a Int128
+		rest, err := a.DecodeMsgpack(data)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if a != tc.want {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.data, tc.want, a)
+		}
+		if len(rest) != 0 {
+			t.Errorf("%d: unexpected rest: %x", i, rest)
+		}
+	}
+}
+
+func TestDecodeMsgpack_Error(t *testing.T) {
+	testCases := []struct {
+		data string
+		uerr error // the error from Uint128.DecodeMsgpack
+		err  error // the error from Int128.DecodeMsgpack
+	}{
+		{"", errMsgpackSyntax, errMsgpackSyntax},
+		{"cc", errMsgpackSyntax, errMsgpackSyntax},
+		{"cf00000000000000", errMsgpackSyntax, errMsgpackSyntax},
+		{"d3ffffffffffffff", errMsgpackSyntax, errMsgpackSyntax},
+		{"d810" + "000000000000000100000000000000", errMsgpackSyntax, errMsgpackSyntax},
+		{"d8", errMsgpackSyntax, errMsgpackSyntax},
+		{"c7", errMsgpackSyntax, errMsgpackSyntax},
+		{"c70810" + "0000000000000001", errMsgpackSyntax, errMsgpackSyntax},
+		{"c71110" + "0000000000000000000000000000000001", errMsgpackSyntax, errMsgpackSyntax},
+
+		// not integers
+		{"c0", errMsgpackType, errMsgpackType},
+		{"c3", errMsgpackType, errMsgpackType},
+		{"a161", errMsgpackType, errMsgpackType},
+		{"cb3ff0000000000000", errMsgpackType, errMsgpackType},
+		{"d812" + "00000000000000010000000000000000", errMsgpackType, errMsgpackType},
+		{"d40001", errMsgpackType, errMsgpackType},
+
+		// out of range
+		{"ff", errMsgpackRange, nil},
+		{"d3ffffffffffffffff", errMsgpackRange, nil},
+		{"d811" + "ffffffffffffffffffffffffffffffff", errMsgpackRange, nil},
+		{"d810" + "80000000000000000000000000000000", nil, errMsgpackRange},
+	}
+
+	for i, tc := range testCases {
+		data, err := hex.DecodeString(tc.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u Uint128
+		if _, err := u.DecodeMsgpack(data); err != tc.uerr {
+			t.Errorf("%d: Uint128.DecodeMsgpack(%s) should %v, but %v", i, tc.data, tc.uerr, err)
+		}
+		var s Int128
+		if _, err := s.DecodeMsgpack(data); err != tc.err {
+			t.Errorf("%d: Int128.DecodeMsgpack(%s) should %v, but %v", i, tc.data, tc.err, err)
+		}
+	}
+}
+
+func TestUint128_MsgpackQuick(t *testing.T) {
+	f := func(a Uint128, shift uint8) bool {
+		a = a.Rsh(uint(shift % 128))
+		var b Uint128
+		rest, err := b.DecodeMsgpack(a.AppendMsgpack(nil))
+		return err == nil && len(rest) == 0 && a == b
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_MsgpackQuick(t *testing.T) {
+	f := func(a Int128, shift uint8) bool {
+		a = a.Rsh(uint(shift % 128))
+		var b Int128
+		rest, err := b.DecodeMsgpack(a.AppendMsgpack(nil))
+		return err == nil && len(rest) == 0 && a == b
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzDecodeMsgpack(f *testing.F) {
+	f.Add([]byte{0x00})
+	f.Add([]byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0})
+	f.Add(append([]byte{0xd8, MsgpackExtInt128}, make([]byte, 16)...))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var u Uint128
+		if _, err := u.DecodeMsgpack(data); err == nil {
+			var v Uint128
+			if _, err := v.DecodeMsgpack(u.AppendMsgpack(nil)); err != nil || u != v {
+				t.Errorf("%x: round trip of %#v failed: %#v, %v", data, u, v, err)
+			}
+		}
+		var s Int128
+		if _, err := s.DecodeMsgpack(data); err == nil {
+			var v Int128
+			if _, err := v.DecodeMsgpack(s.AppendMsgpack(nil)); err != nil || s != v {
+				t.Errorf("%x: round trip of %#v failed: %#v, %v", data, s, v, err)
+			}
+		}
+	})
+}
+
+func BenchmarkUint128_AppendMsgpack(b *testing.B) {
+	a := Uint128{math.MaxUint64, math.MaxUint64}
+	buf := make([]byte, 0, 32)
+	for i := 0; i < b.N; i++ {
+		buf = a.AppendMsgpack(buf[:0])
+	}
+}
+
+func BenchmarkUint128_DecodeMsgpack(b *testing.B) {
+	data := Uint128{math.MaxUint64, math.MaxUint64}.AppendMsgpack(nil)
+	var a Uint128
+	for i := 0; i < b.N; i++ {
+		if _, err := a.DecodeMsgpack(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package int128
+
+import "net/netip"
+
+// Uint128FromAddr returns the 128-bit integer of the IP address addr.
+// An IPv4 address is converted into its IPv4-mapped IPv6 address ::ffff:a.b.c.d.
+// The zero Addr is converted into 0.
+func Uint128FromAddr(addr netip.Addr) Uint128 {
+	return Uint128FromBytes(addr.As16())
+}
+
+// Addr returns a as an IPv6 address.
+// Use [netip.Addr.Unmap] to get the IPv4 address from an IPv4-mapped IPv6 address.
+func (a Uint128) Addr() netip.Addr {
+	return netip.AddrFrom16(a.Bytes())
+}
+
+// PrefixMask returns the mask of an IPv6 prefix of length bits,
+// i.e. the value whose upper bits are one and the rest are zero.
+// bits must be between 0 and 128, inclusive; otherwise PrefixMask panics.
+func PrefixMask(bits int) Uint128 {
+	if bits < 0 || bits > 128 {
+		panic("int128: prefix length out of range")
+	}
+	max := Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}
+	return max.Lsh(uint(128 - bits))
+}
+
+// PrefixRange returns the first and the last addresses of the prefix p.
+// An IPv4 prefix is converted into the IPv4-mapped IPv6 range.
+// If p is invalid, PrefixRange returns 0 and 0.
+func PrefixRange(p netip.Prefix) (first, last Uint128) {
+	if !p.IsValid() {
+		return Uint128{}, Uint128{}
+	}
+	bits := p.Bits()
+	if p.Addr().Is4() {
+		bits += 96
+	}
+	mask := PrefixMask(bits)
+	first = Uint128FromAddr(p.Addr()).And(mask)
+	last = first.Or(mask.Not())
+	return first, last
+}
+
+// RangePrefixes returns the minimal list of IPv6 prefixes
+// that covers the range of addresses [first, last] exactly, in ascending order.
+// It returns nil if first > last.
+func RangePrefixes(first, last Uint128) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for first.Cmp(last) <= 0 {
+		// the size of the prefix is limited by the alignment of first
+		// and by the number of the remaining addresses.
+		n := last.Sub(first) // the number of the remaining addresses - 1
+		size := 128
+		if n.Not().H != 0 || n.Not().L != 0 {
+			size = n.Add(Uint128{0, 1}).Len() - 1
+		}
+		if tz := first.TrailingZeros(); tz < size {
+			size = tz
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(first.Addr(), 128-size))
+
+		if size == 128 {
+			break
+		}
+		first = first.Add(Uint128{0, 1}.Lsh(uint(size)))
+		if first.H == 0 && first.L == 0 {
+			// wrap around; the range reaches the last address.
+			break
+		}
+	}
+	return prefixes
+}
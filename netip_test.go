@@ -0,0 +1,161 @@
+package int128
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestUint128FromAddr(t *testing.T) {
+	testCases := []struct {
+		addr string
+		want Uint128
+	}{
+		{"::", Uint128{0, 0}},
+		{"::1", Uint128{0, 1}},
+		{"2001:db8::1", Uint128{0x2001_0db8_0000_0000, 1}},
+		{"192.0.2.1", Uint128{0, 0x0000_ffff_c000_0201}},
+		{"::ffff:192.0.2.1", Uint128{0, 0x0000_ffff_c000_0201}},
+	}
+	for i, tc := range testCases {
+		addr := netip.MustParseAddr(tc.addr)
+		got := Uint128FromAddr(addr)
+		if got != tc.want {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.addr, tc.want, got)
+		}
+		if back := got.Addr(); back.Unmap() != addr.Unmap() {
+			t.Errorf("%d: %#v should %s, but %s", i, got, addr, back)
+		}
+	}
+}
+
+func TestUint128_AddrQuick(t *testing.T) {
+	f := func(a Uint128) bool {
+		return Uint128FromAddr(a.Addr()) == a
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrefixMask(t *testing.T) {
+	testCases := []struct {
+		bits int
+		want Uint128
+	}{
+		{0, Uint128{0, 0}},
+		{1, Uint128{0x8000_0000_0000_0000, 0}},
+		{64, Uint128{0xffff_ffff_ffff_ffff, 0}},
+		{127, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_fffe}},
+		{128, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+	}
+	for i, tc := range testCases {
+		if got := PrefixMask(tc.bits); got != tc.want {
+			t.Errorf("%d: PrefixMask(%d) should %#v, but %#v", i, tc.bits, tc.want, got)
+		}
+	}
+}
+
+func TestPrefixRange(t *testing.T) {
+	testCases := []struct {
+		prefix      string
+		first, last string
+	}{
+		{"2001:db8::/32", "2001:db8::", "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"2001:db8::1/128", "2001:db8::1", "2001:db8::1"},
+		{"::/0", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"192.0.2.123/24", "::ffff:192.0.2.0", "::ffff:192.0.2.255"},
+	}
+	for i, tc := range testCases {
+		first, last := PrefixRange(netip.MustParsePrefix(tc.prefix))
+		if want := Uint128FromAddr(netip.MustParseAddr(tc.first)); first != want {
+			t.Errorf("%d: the first address of %s should %s, but %s", i, tc.prefix, want.Addr(), first.Addr())
+		}
+		if want := Uint128FromAddr(netip.MustParseAddr(tc.last)); last != want {
+			t.Errorf("%d: the last address of %s should %s, but %s", i, tc.prefix, want.Addr(), last.Addr())
+		}
+	}
+}
+
+func TestRangePrefixes(t *testing.T) {
+	testCases := []struct {
+		first, last string
+		want        []string
+	}{
+		{"2001:db8::", "2001:db8::", []string{"2001:db8::/128"}},
+		{"2001:db8::", "2001:db8::ff", []string{"2001:db8::/120"}},
+		{"2001:db8::1", "2001:db8::6", []string{"2001:db8::1/128", "2001:db8::2/127", "2001:db8::4/127", "2001:db8::6/128"}},
+		{"::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"::/0"}},
+		{"::2", "::1", nil},
+	}
+	for i, tc := range testCases {
+		first := Uint128FromAddr(netip.MustParseAddr(tc.first))
+		last := Uint128FromAddr(netip.MustParseAddr(tc.last))
+		got := RangePrefixes(first, last)
+		var want []netip.Prefix
+		for _, p := range tc.want {
+			want = append(want, netip.MustParsePrefix(p))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: [%s, %s] should %v, but %v", i, tc.first, tc.last, want, got)
+		}
+	}
+}
+
+func TestRangePrefixes_AllButZero(t *testing.T) {
+	// ::1/128, ::2/127, ::4/126, ..., 8000::/1
+	got := RangePrefixes(Uint128{0, 1}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff})
+	if len(got) != 128 {
+		t.Fatalf("want 128 prefixes, got %d", len(got))
+	}
+	for i, p := range got {
+		if p.Bits() != 128-i {
+			t.Errorf("%d: want /%d, got %s", i, 128-i, p)
+		}
+	}
+}
+
+func TestRangePrefixesQuick(t *testing.T) {
+	f := func(first, last Uint128, small uint16) bool {
+		if first.Cmp(last) > 0 {
+			first, last = last, first
+		}
+		if small&1 != 0 {
+			// a small range has more interesting boundaries.
+			last = first.Add(Uint128{0, uint64(small)})
+			if last.Cmp(first) < 0 {
+				return true
+			}
+		}
+		prefixes := RangePrefixes(first, last)
+
+		// the prefixes cover the range exactly.
+		next := first
+		for i, p := range prefixes {
+			pf, pl := PrefixRange(p)
+			if pf != next {
+				return false
+			}
+			if i == len(prefixes)-1 {
+				if pl != last {
+					return false
+				}
+			} else {
+				next = pl.Add(Uint128{0, 1})
+			}
+
+			// the adjacent prefixes can't be merged.
+			if i > 0 && prefixes[i-1].Bits() == p.Bits() {
+				merged := netip.PrefixFrom(prefixes[i-1].Addr(), p.Bits()-1)
+				if merged.Masked() == merged {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCountScale: 10}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,83 @@
+package int128
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrSyntax indicates that a value does not have the right syntax for the target type.
+var ErrSyntax = errors.New("invalid syntax")
+
+// ErrRange indicates that a value is out of range for the target type.
+var ErrRange = errors.New("value out of range")
+
+// parseUint128 parses the decimal representation of an unsigned integer, such as "12345".
+// As with strconv.ParseUint, leading zeros are allowed but signs are not.
+func parseUint128(s []byte) (Uint128, error) {
+	v, err := parseDecimal(s)
+	if err != nil {
+		return Uint128{}, fmt.Errorf("int128: parsing %q: %w", s, err)
+	}
+	return v, nil
+}
+
+// parseInt128 parses the decimal representation of a signed integer, such as "-12345".
+func parseInt128(s []byte) (Int128, error) {
+	neg := false
+	digits := s
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		neg = digits[0] == '-'
+		digits = digits[1:]
+	}
+	mag, err := parseDecimal(digits)
+	if err != nil {
+		return Int128{}, fmt.Errorf("int128: parsing %q: %w", s, err)
+	}
+	v, ok := Int128FromSignMagnitude(neg, mag)
+	if !ok {
+		return Int128{}, fmt.Errorf("int128: parsing %q: %w", s, ErrRange)
+	}
+	return v, nil
+}
+
+// parseDecimal parses the decimal digits in 19-digit chunks.
+func parseDecimal(s []byte) (Uint128, error) {
+	if len(s) == 0 {
+		return Uint128{}, ErrSyntax
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return Uint128{}, ErrSyntax
+		}
+	}
+
+	var v Uint128
+	n := len(s) % 19
+	if n == 0 {
+		n = 19
+	}
+	for len(s) > 0 {
+		var chunk uint64
+		for _, c := range s[:n] {
+			chunk = chunk*10 + uint64(c-'0')
+		}
+		s = s[n:]
+
+		// v = v*10**n + chunk
+		hi, lo := bits.Mul64(v.L, pow10tab[n].L)
+		ovf, h := bits.Mul64(v.H, pow10tab[n].L)
+		h, carry := bits.Add64(h, hi, 0)
+		if ovf != 0 || carry != 0 {
+			return Uint128{}, ErrRange
+		}
+		lo, carry = bits.Add64(lo, chunk, 0)
+		h, carry = bits.Add64(h, 0, carry)
+		if carry != 0 {
+			return Uint128{}, ErrRange
+		}
+		v = Uint128{h, lo}
+		n = 19
+	}
+	return v, nil
+}
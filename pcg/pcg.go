@@ -0,0 +1,209 @@
+// Package pcg implements the PCG family of pseudo-random number generators
+// with a 128-bit linear congruential state.
+//
+// The state is advanced by the LCG step
+//
+//	state = state*Multiplier() + inc
+//
+// and each step yields a 64-bit output through a permutation:
+// XSLRR implements the PCG64 (XSL-RR) output function of the reference implementation,
+// and DXSM implements the DXSM output function used by [math/rand/v2.PCG].
+// Both types implement [math/rand/v2.Source].
+//
+// The generators are not suitable for security-sensitive work.
+package pcg
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/shogo82148/int128"
+)
+
+var (
+	multiplier       = int128.Uint128{H: 0x2360ed051fc65da4, L: 0x4385df649fccf645}
+	defaultIncrement = int128.Uint128{H: 0x5851f42d4c957f2d, L: 0x14057b7ef767814f}
+)
+
+// Multiplier returns the multiplier of the LCG step.
+func Multiplier() int128.Uint128 {
+	return multiplier
+}
+
+// DefaultIncrement returns the increment of the LCG step used by [math/rand/v2.PCG].
+func DefaultIncrement() int128.Uint128 {
+	return defaultIncrement
+}
+
+// PCG is the 128-bit LCG state shared by the PCG generators.
+// The zero value is not ready to use; use Seed or SetState.
+type PCG struct {
+	state int128.Uint128
+	inc   int128.Uint128
+
+	// origin is the state right after seeding; Seek counts the steps from it.
+	origin int128.Uint128
+}
+
+// Seed initializes the state with the seed and the stream selector seq,
+// in the same way as pcg_setseq_128_srandom_r of the reference implementation.
+// Generators with different seq values produce different streams.
+func (p *PCG) Seed(seed, seq int128.Uint128) {
+	p.state = int128.Uint128{}
+	p.inc = seq.Lsh(1).Or(int128.Uint128{H: 0, L: 1})
+	p.step()
+	p.state = p.state.Add(seed)
+	p.step()
+	p.origin = p.state
+}
+
+// SetState sets the raw LCG state and the increment.
+// The increment must be odd; SetState sets the lowest bit of inc.
+func (p *PCG) SetState(state, inc int128.Uint128) {
+	p.state = state
+	p.inc = inc.Or(int128.Uint128{H: 0, L: 1})
+	p.origin = state
+}
+
+// State returns the raw LCG state and the increment.
+func (p *PCG) State() (state, inc int128.Uint128) {
+	return p.state, p.inc
+}
+
+func (p *PCG) step() {
+	p.state = p.state.Mul(multiplier).Add(p.inc)
+}
+
+// Advance moves the generator delta steps forward in O(log delta) time.
+// Because the period is 2**128, p.Advance(d.Neg()) moves it d steps backward.
+//
+// See "Random Number Generation with Arbitrary Strides", F. B. Brown.
+func (p *PCG) Advance(delta int128.Uint128) {
+	accMul := int128.Uint128{H: 0, L: 1}
+	accAdd := int128.Uint128{}
+	curMul := multiplier
+	curAdd := p.inc
+	for delta.H != 0 || delta.L != 0 {
+		if delta.L&1 != 0 {
+			accMul = accMul.Mul(curMul)
+			accAdd = accAdd.Mul(curMul).Add(curAdd)
+		}
+		curAdd = curMul.Add(int128.Uint128{H: 0, L: 1}).Mul(curAdd)
+		curMul = curMul.Mul(curMul)
+		delta = delta.Rsh(1)
+	}
+	p.state = accMul.Mul(p.state).Add(accAdd)
+}
+
+// Seek moves the generator to the position n,
+// i.e. the n-th step from the last Seed or SetState.
+func (p *PCG) Seek(n int128.Uint128) {
+	p.state = p.origin
+	p.Advance(n)
+}
+
+const binaryPrefix = "pcg128:"
+
+var errUnmarshal = errors.New("pcg: invalid binary data")
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+// The snapshot contains the state, the increment and the origin of Seek.
+func (p *PCG) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, len(binaryPrefix)+48)
+	buf = append(buf, binaryPrefix...)
+	buf = appendUint128(buf, p.state)
+	buf = appendUint128(buf, p.inc)
+	buf = appendUint128(buf, p.origin)
+	return buf, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+func (p *PCG) UnmarshalBinary(data []byte) error {
+	if len(data) != len(binaryPrefix)+48 || string(data[:len(binaryPrefix)]) != binaryPrefix {
+		return errUnmarshal
+	}
+	data = data[len(binaryPrefix):]
+	inc := readUint128(data[16:])
+	if inc.L&1 == 0 {
+		return errUnmarshal
+	}
+	p.state = readUint128(data)
+	p.inc = inc
+	p.origin = readUint128(data[32:])
+	return nil
+}
+
+func appendUint128(buf []byte, v int128.Uint128) []byte {
+	for i := 56; i >= 0; i -= 8 {
+		buf = append(buf, byte(v.H>>i))
+	}
+	for i := 56; i >= 0; i -= 8 {
+		buf = append(buf, byte(v.L>>i))
+	}
+	return buf
+}
+
+func readUint128(data []byte) int128.Uint128 {
+	var v int128.Uint128
+	for i := 0; i < 8; i++ {
+		v.H = v.H<<8 | uint64(data[i])
+		v.L = v.L<<8 | uint64(data[i+8])
+	}
+	return v
+}
+
+// XSLRR is a PCG generator with the XSL-RR output function,
+// also known as PCG64.
+type XSLRR struct {
+	PCG
+}
+
+// NewXSLRR returns a new XSLRR generator seeded with seed and seq.
+func NewXSLRR(seed, seq int128.Uint128) *XSLRR {
+	g := new(XSLRR)
+	g.Seed(seed, seq)
+	return g
+}
+
+// Uint64 advances the generator and returns a pseudo-random uint64.
+func (g *XSLRR) Uint64() uint64 {
+	g.step()
+	return OutputXSLRR(g.state)
+}
+
+// OutputXSLRR is the XSL-RR output function:
+// it xors the two halves of the state and rotates the result by the top 6 bits.
+func OutputXSLRR(state int128.Uint128) uint64 {
+	return bits.RotateLeft64(state.H^state.L, -int(state.H>>58))
+}
+
+// DXSM is a PCG generator with the DXSM output function.
+// It produces the same sequence as [math/rand/v2.PCG]
+// if it is initialized by SetState(int128.Uint128{H: seed1, L: seed2}, DefaultIncrement()).
+type DXSM struct {
+	PCG
+}
+
+// NewDXSM returns a new DXSM generator seeded with seed and seq.
+func NewDXSM(seed, seq int128.Uint128) *DXSM {
+	g := new(DXSM)
+	g.Seed(seed, seq)
+	return g
+}
+
+// Uint64 advances the generator and returns a pseudo-random uint64.
+func (g *DXSM) Uint64() uint64 {
+	g.step()
+	return OutputDXSM(g.state)
+}
+
+// OutputDXSM is the DXSM (double xorshift multiply) output function.
+func OutputDXSM(state int128.Uint128) uint64 {
+	const cheapMul = 0xda942042e4dd58b5
+	hi := state.H
+	hi ^= hi >> 32
+	hi *= cheapMul
+	hi ^= hi >> 48
+	hi *= state.L | 1
+	return hi
+}
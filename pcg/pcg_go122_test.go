@@ -0,0 +1,24 @@
+//go:build go1.22
+
+package pcg
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/shogo82148/int128"
+)
+
+var _ rand.Source = (*DXSM)(nil)
+var _ rand.Source = (*XSLRR)(nil)
+
+func TestDXSM_CompatibleWithMathRand(t *testing.T) {
+	want := rand.NewPCG(1, 2)
+	var got DXSM
+	got.SetState(int128.Uint128{H: 1, L: 2}, DefaultIncrement())
+	for i := 0; i < 100; i++ {
+		if a, b := want.Uint64(), got.Uint64(); a != b {
+			t.Fatalf("%d: want %#x, got %#x", i, a, b)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package pcg
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+
+	"github.com/shogo82148/int128"
+)
+
+func TestXSLRR(t *testing.T) {
+	// the expected values are from the check program of the reference implementation:
+	// pcg64_srandom_r(&rng, 42u, 54u)
+	g := NewXSLRR(int128.Uint128{H: 0, L: 42}, int128.Uint128{H: 0, L: 54})
+	want := []uint64{
+		0x86b1da1d72062b68,
+		0x1304aa46c9853d39,
+		0xa3670e9e0dd50358,
+		0xf9090e529a7dae00,
+		0xc85b9fd837996f2c,
+		0x606121f8e3919196,
+	}
+	for i, w := range want {
+		if got := g.Uint64(); got != w {
+			t.Errorf("%d: want %#x, got %#x", i, w, got)
+		}
+	}
+}
+
+func TestPCG_Advance(t *testing.T) {
+	g1 := NewDXSM(int128.Uint128{H: 1, L: 2}, int128.Uint128{H: 3, L: 4})
+	g2 := NewDXSM(int128.Uint128{H: 1, L: 2}, int128.Uint128{H: 3, L: 4})
+	for i := 0; i < 1000; i++ {
+		g1.Uint64()
+	}
+	g2.Advance(int128.Uint128{H: 0, L: 1000})
+	if g1.PCG != g2.PCG {
+		t.Errorf("want %#v, got %#v", g1.PCG, g2.PCG)
+	}
+
+	// move backward
+	want := g1.Uint64()
+	g1.Advance(int128.Uint128{H: 0, L: 1}.Neg())
+	if got := g1.Uint64(); got != want {
+		t.Errorf("want %#x, got %#x", want, got)
+	}
+}
+
+func TestPCG_AdvanceQuick(t *testing.T) {
+	f := func(state, inc int128.Uint128, a, b int128.Uint128) bool {
+		// advancing a and then b is the same as advancing a+b.
+		var p1, p2 PCG
+		p1.SetState(state, inc)
+		p2.SetState(state, inc)
+		p1.Advance(a)
+		p1.Advance(b)
+		p2.Advance(a.Add(b))
+		return p1 == p2
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPCG_Seek(t *testing.T) {
+	g := NewXSLRR(int128.Uint128{H: 0, L: 42}, int128.Uint128{H: 0, L: 54})
+	var want [10]uint64
+	for i := range want {
+		want[i] = g.Uint64()
+	}
+
+	g.Seek(int128.Uint128{H: 0, L: 5})
+	for i := 5; i < len(want); i++ {
+		if got := g.Uint64(); got != want[i] {
+			t.Errorf("%d: want %#x, got %#x", i, want[i], got)
+		}
+	}
+	g.Seek(int128.Uint128{})
+	if got := g.Uint64(); got != want[0] {
+		t.Errorf("want %#x, got %#x", want[0], got)
+	}
+}
+
+func TestPCG_MarshalBinary(t *testing.T) {
+	g1 := NewDXSM(int128.Uint128{H: 0, L: 42}, int128.Uint128{H: 0, L: 54})
+	g1.Uint64()
+	data, err := g1.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("pcg128:")) {
+		t.Errorf("unexpected prefix: %q", data)
+	}
+
+	var g2 DXSM
+	if err := g2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if a, b := g1.Uint64(), g2.Uint64(); a != b {
+			t.Errorf("%d: want %#x, got %#x", i, a, b)
+		}
+	}
+
+	// Seek works after restoring the snapshot.
+	g1.Seek(int128.Uint128{})
+	g2.Seek(int128.Uint128{})
+	if g1.PCG != g2.PCG {
+		t.Errorf("want %#v, got %#v", g1.PCG, g2.PCG)
+	}
+
+	invalid := [][]byte{
+		nil,
+		[]byte("pcg128:"),
+		append([]byte("pcg64::"), data[len("pcg128:"):]...),
+		append(append([]byte{}, data[:len(data)-33]...), make([]byte, 33)...), // even increment
+	}
+	for i, d := range invalid {
+		var g DXSM
+		if err := g.UnmarshalBinary(d); err == nil {
+			t.Errorf("%d: want error, got nil", i)
+		}
+	}
+}
+
+func BenchmarkXSLRR(b *testing.B) {
+	g := NewXSLRR(int128.Uint128{H: 0, L: 42}, int128.Uint128{H: 0, L: 54})
+	for i := 0; i < b.N; i++ {
+		g.Uint64()
+	}
+}
+
+func BenchmarkDXSM(b *testing.B) {
+	g := NewDXSM(int128.Uint128{H: 0, L: 42}, int128.Uint128{H: 0, L: 54})
+	for i := 0; i < b.N; i++ {
+		g.Uint64()
+	}
+}
@@ -0,0 +1,178 @@
+package int128
+
+// pow10tab is the table of the powers of ten that Uint128 can represent.
+var pow10tab = [...]Uint128{
+	{0, 1},
+	{0, 10},
+	{0, 100},
+	{0, 1000},
+	{0, 10000},
+	{0, 100000},
+	{0, 1000000},
+	{0, 10000000},
+	{0, 100000000},
+	{0, 1000000000},
+	{0, 10000000000},
+	{0, 100000000000},
+	{0, 1000000000000},
+	{0, 10000000000000},
+	{0, 100000000000000},
+	{0, 1000000000000000},
+	{0, 10000000000000000},
+	{0, 100000000000000000},
+	{0, 1000000000000000000},
+	{0, 10000000000000000000},
+	{0x0000000000000005, 0x6bc75e2d63100000}, // 1e20
+	{0x0000000000000036, 0x35c9adc5dea00000}, // 1e21
+	{0x000000000000021e, 0x19e0c9bab2400000}, // 1e22
+	{0x000000000000152d, 0x02c7e14af6800000}, // 1e23
+	{0x000000000000d3c2, 0x1bcecceda1000000}, // 1e24
+	{0x0000000000084595, 0x161401484a000000}, // 1e25
+	{0x000000000052b7d2, 0xdcc80cd2e4000000}, // 1e26
+	{0x00000000033b2e3c, 0x9fd0803ce8000000}, // 1e27
+	{0x00000000204fce5e, 0x3e25026110000000}, // 1e28
+	{0x00000001431e0fae, 0x6d7217caa0000000}, // 1e29
+	{0x0000000c9f2c9cd0, 0x4674edea40000000}, // 1e30
+	{0x0000007e37be2022, 0xc0914b2680000000}, // 1e31
+	{0x000004ee2d6d415b, 0x85acef8100000000}, // 1e32
+	{0x0000314dc6448d93, 0x38c15b0a00000000}, // 1e33
+	{0x0001ed09bead87c0, 0x378d8e6400000000}, // 1e34
+	{0x0013426172c74d82, 0x2b878fe800000000}, // 1e35
+	{0x00c097ce7bc90715, 0xb34b9f1000000000}, // 1e36
+	{0x0785ee10d5da46d9, 0x00f436a000000000}, // 1e37
+	{0x4b3b4ca85a86c47a, 0x098a224000000000}, // 1e38
+}
+
+// Pow10Uint128 returns 10**n.
+// n must be between 0 and 38, inclusive; otherwise Pow10Uint128 panics.
+func Pow10Uint128(n uint) Uint128 {
+	if n >= uint(len(pow10tab)) {
+		panic("int128: Pow10Uint128 exponent out of range")
+	}
+	return pow10tab[n]
+}
+
+// Pow10Int128 returns 10**n.
+// n must be between 0 and 38, inclusive; otherwise Pow10Int128 panics.
+func Pow10Int128(n uint) Int128 {
+	if n >= uint(len(pow10tab)) {
+		panic("int128: Pow10Int128 exponent out of range")
+	}
+	return pow10tab[n].Int128()
+}
+
+// mulOverflow returns the product a*b and reports whether the product fits in Uint128.
+func mulOverflow(a, b Uint128) (Uint128, bool) {
+	hi, lo := mul128(a, b)
+	return lo, hi.H == 0 && hi.L == 0
+}
+
+// Pow returns a**n, and reports whether the result fits in Uint128.
+// If the result overflows, the returned value wraps around.
+// Pow(0) returns 1 for any a.
+func (a Uint128) Pow(n uint) (Uint128, bool) {
+	ret := Uint128{0, 1}
+	ok := true
+	for n != 0 {
+		var o bool
+		if n&1 != 0 {
+			ret, o = mulOverflow(ret, a)
+			ok = ok && o
+		}
+		n >>= 1
+		if n != 0 {
+			// if a*a overflows, the final result also overflows
+			// because it will be multiplied by a**(2**k) later.
+			a, o = mulOverflow(a, a)
+			ok = ok && o
+		}
+	}
+	return ret, ok
+}
+
+// Pow returns a**n, and reports whether the result fits in Int128.
+// If the result overflows, the returned value wraps around.
+// Pow(0) returns 1 for any a.
+func (a Int128) Pow(n uint) (Int128, bool) {
+	neg := a.IsNeg() && n&1 != 0
+	ret, ok := a.AbsUint128().Pow(n)
+	v, fit := Int128FromSignMagnitude(neg, ret)
+	return v, ok && fit
+}
+
+// MulPow10 returns a*10**n, and reports whether the result fits in Uint128.
+// If the result overflows, the returned value wraps around.
+func (a Uint128) MulPow10(n uint) (Uint128, bool) {
+	if n < uint(len(pow10tab)) {
+		return mulOverflow(a, pow10tab[n])
+	}
+	p, _ := Uint128{0, 10}.Pow(n)
+	return a.Mul(p), a.H == 0 && a.L == 0
+}
+
+// MulPow10 returns a*10**n, and reports whether the result fits in Int128.
+// If the result overflows, the returned value wraps around.
+func (a Int128) MulPow10(n uint) (Int128, bool) {
+	ret, ok := a.AbsUint128().MulPow10(n)
+	v, fit := Int128FromSignMagnitude(a.IsNeg(), ret)
+	return v, ok && fit
+}
+
+// DivPow10 returns the quotient a/10**n rounded according to mode.
+func (a Uint128) DivPow10(n uint, mode RoundingMode) Uint128 {
+	return divPow10(a, n, false, mode)
+}
+
+// DivPow10 returns the quotient a/10**n rounded according to mode.
+func (a Int128) DivPow10(n uint, mode RoundingMode) Int128 {
+	neg := a.IsNeg()
+	ret := divPow10(a.AbsUint128(), n, neg, mode)
+	if neg {
+		ret = ret.Neg()
+	}
+	return ret.Int128()
+}
+
+// divPow10 divides the magnitude a by 10**n, and rounds the quotient.
+// neg is the sign of the dividend.
+func divPow10(a Uint128, n uint, neg bool, mode RoundingMode) Uint128 {
+	if n >= uint(len(pow10tab)) {
+		// 10**n is greater than 2*a, so the quotient is 0 and
+		// the remainder is always less than the half of the divisor.
+		if mode == ToNearestEven || mode == ToNearestAway {
+			return Uint128{}
+		}
+		if mode.roundUp(Uint128{}, a, a, neg) {
+			return Uint128{0, 1}
+		}
+		return Uint128{}
+	}
+
+	q, r := divmodPow10(a, n)
+	if mode.roundUp(q, r, pow10tab[n], neg) {
+		q = q.Add(Uint128{0, 1})
+	}
+	return q
+}
+
+// IsPowerOfTwo reports whether a is a power of two.
+func (a Uint128) IsPowerOfTwo() bool {
+	if a.H == 0 {
+		return a.L != 0 && a.L&(a.L-1) == 0
+	}
+	return a.L == 0 && a.H&(a.H-1) == 0
+}
+
+// NextPowerOfTwo returns the smallest power of two greater than or equal to a,
+// and reports whether the result fits in Uint128.
+// If it doesn't fit, NextPowerOfTwo returns 0.
+func (a Uint128) NextPowerOfTwo() (Uint128, bool) {
+	if a.H == 0 && a.L <= 1 {
+		return Uint128{0, 1}, true
+	}
+	n := a.Sub(Uint128{0, 1}).Len()
+	if n >= 128 {
+		return Uint128{}, false
+	}
+	return Uint128{0, 1}.Lsh(uint(n)), true
+}
@@ -0,0 +1,315 @@
+package int128
+
+import (
+	"math/big"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+func TestPow10Uint128(t *testing.T) {
+	want := big.NewInt(1)
+	ten := big.NewInt(10)
+	for i := uint(0); i <= 38; i++ {
+		got := Pow10Uint128(i)
+		if uint128ToBig(new(big.Int), got).Cmp(want) != 0 {
+			t.Errorf("10**%d should %s, but %s", i, want, got)
+		}
+		if Pow10Int128(i).Uint128() != got {
+			t.Errorf("10**%d should %s, but %s", i, want, Pow10Int128(i))
+		}
+		want.Mul(want, ten)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("want panic, but not")
+			}
+		}()
+		Pow10Uint128(39)
+	}()
+}
+
+func TestUint128_Pow(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		n    uint
+		want Uint128
+		ok   bool
+	}{
+		{Uint128{0, 0}, 0, Uint128{0, 1}, true},
+		{Uint128{0, 0}, 1, Uint128{0, 0}, true},
+		{Uint128{0, 1}, 1000, Uint128{0, 1}, true},
+		{Uint128{0, 2}, 127, Uint128{0x8000_0000_0000_0000, 0}, true},
+		{Uint128{0, 2}, 128, Uint128{0, 0}, false},
+		{Uint128{0, 10}, 38, Uint128{0x4b3b4ca85a86c47a, 0x098a224000000000}, true},
+		{Uint128{0, 10}, 39, Uint128{0xf050fe938943acc4, 0x5f65568000000000}, false},
+		{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, 1, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, true},
+		{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, 2, Uint128{0, 1}, false},
+	}
+
+	for i, tc := range testCases {
+		got, ok := tc.a.Pow(tc.n)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%d: %#v ** %d should (%#v, %t), but (%#v, %t)", i, tc.a, tc.n, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestUint128_PowQuick(t *testing.T) {
+	f := func(l uint8, a Uint128, n uint8) (Uint128, bool) {
+		a = a.Rsh(uint(l % 128))
+		return a.Pow(uint(n))
+	}
+	g := func(l uint8, a Uint128, n uint8) (Uint128, bool) {
+		a = a.Rsh(uint(l % 128))
+		bigA := uint128ToBig(new(big.Int), a)
+		bigA.Exp(bigA, big.NewInt(int64(n)), nil)
+		return bigToUint128(bigA), bigA.BitLen() <= 128
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkUint128_Pow(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v, ok := uint128Input.Pow(20)
+		runtime.KeepAlive(v)
+		runtime.KeepAlive(ok)
+	}
+}
+
+func TestInt128_Pow(t *testing.T) {
+	testCases := []struct {
+		a    Int128
+		n    uint
+		want Int128
+		ok   bool
+	}{
+		{Int128{0, 0}, 0, Int128{0, 1}, true},
+		{Int128{-1, 0xffff_ffff_ffff_fffe}, 127, Int128{-0x8000_0000_0000_0000, 0}, true},
+		{Int128{-1, 0xffff_ffff_ffff_fffe}, 128, Int128{0, 0}, false},
+		{Int128{0, 2}, 126, Int128{0x4000_0000_0000_0000, 0}, true},
+		{Int128{0, 2}, 127, Int128{-0x8000_0000_0000_0000, 0}, false},
+		{Int128{-1, 0xffff_ffff_ffff_ffff}, 3, Int128{-1, 0xffff_ffff_ffff_ffff}, true},
+		{Int128{-1, 0xffff_ffff_ffff_ffff}, 4, Int128{0, 1}, true},
+	}
+
+	for i, tc := range testCases {
+		got, ok := tc.a.Pow(tc.n)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%d: %#v ** %d should (%#v, %t), but (%#v, %t)", i, tc.a, tc.n, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestInt128_PowQuick(t *testing.T) {
+	f := func(l uint8, a Int128, n uint8) (Int128, bool) {
+		a = a.Rsh(uint(l % 128))
+		return a.Pow(uint(n))
+	}
+	g := func(l uint8, a Int128, n uint8) (Int128, bool) {
+		a = a.Rsh(uint(l % 128))
+		bigA := int128ToBig(new(big.Int), a)
+		bigA.Exp(bigA, big.NewInt(int64(n)), nil)
+		return bigToInt128(bigA), fitsInt128(bigA)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func fitsInt128(x *big.Int) bool {
+	lo := new(big.Int).Lsh(big.NewInt(-1), 127)
+	hi := new(big.Int).Lsh(big.NewInt(1), 127)
+	return x.Cmp(lo) >= 0 && x.Cmp(hi) < 0
+}
+
+func TestUint128_MulPow10Quick(t *testing.T) {
+	f := func(l uint8, a Uint128, n uint8) (Uint128, bool) {
+		a = a.Rsh(uint(l % 128))
+		return a.MulPow10(uint(n % 48))
+	}
+	g := func(l uint8, a Uint128, n uint8) (Uint128, bool) {
+		a = a.Rsh(uint(l % 128))
+		bigA := uint128ToBig(new(big.Int), a)
+		bigA.Mul(bigA, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n%48)), nil))
+		return bigToUint128(bigA), bigA.BitLen() <= 128
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInt128_MulPow10Quick(t *testing.T) {
+	f := func(l uint8, a Int128, n uint8) (Int128, bool) {
+		a = a.Rsh(uint(l % 128))
+		return a.MulPow10(uint(n % 48))
+	}
+	g := func(l uint8, a Int128, n uint8) (Int128, bool) {
+		a = a.Rsh(uint(l % 128))
+		bigA := int128ToBig(new(big.Int), a)
+		bigA.Mul(bigA, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n%48)), nil))
+		return bigToInt128(bigA), fitsInt128(bigA)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128_DivPow10(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		n    uint
+		mode RoundingMode
+		want Uint128
+	}{
+		{Uint128{0, 15}, 1, ToNearestEven, Uint128{0, 2}},
+		{Uint128{0, 25}, 1, ToNearestEven, Uint128{0, 2}},
+		{Uint128{0, 25}, 1, ToNearestAway, Uint128{0, 3}},
+		{Uint128{0, 25}, 1, ToZero, Uint128{0, 2}},
+		{Uint128{0, 21}, 1, AwayFromZero, Uint128{0, 3}},
+		{Uint128{0, 29}, 1, ToNegativeInf, Uint128{0, 2}},
+		{Uint128{0, 21}, 1, ToPositiveInf, Uint128{0, 3}},
+		{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, 39, ToNearestEven, Uint128{0, 0}},
+		{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, 39, ToPositiveInf, Uint128{0, 1}},
+		{Uint128{0, 0}, 39, ToPositiveInf, Uint128{0, 0}},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.DivPow10(tc.n, tc.mode)
+		if got != tc.want {
+			t.Errorf("%d: %#v / 10**%d (%s) should %#v, but %#v", i, tc.a, tc.n, tc.mode, tc.want, got)
+		}
+	}
+}
+
+func TestUint128_DivPow10Quick(t *testing.T) {
+	for _, mode := range roundingModes {
+		mode := mode
+		f := func(l uint8, a Uint128, n uint8) Uint128 {
+			a = a.Rsh(uint(l % 128))
+			return a.DivPow10(uint(n%48), mode)
+		}
+		g := func(l uint8, a Uint128, n uint8) Uint128 {
+			a = a.Rsh(uint(l % 128))
+			bigA := uint128ToBig(new(big.Int), a)
+			bigB := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n%48)), nil)
+			return bigToUint128(bigQuoRound(bigA, bigB, mode))
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+	}
+}
+
+func TestDivmodPow10(t *testing.T) {
+	// divmodPow10 multiplies with the reciprocals on the architectures other than amd64,
+	// and with the purego build tag.
+	for n := range pow10tab {
+		n := uint(n)
+		f := func(l uint8, a Uint128) [2]Uint128 {
+			a = a.Rsh(uint(l % 128))
+			q, r := divmodPow10(a, n)
+			return [2]Uint128{q, r}
+		}
+		g := func(l uint8, a Uint128) [2]Uint128 {
+			a = a.Rsh(uint(l % 128))
+			q, r := a.DivMod(pow10tab[n])
+			return [2]Uint128{q, r}
+		}
+		if err := quick.CheckEqual(f, g, nil); err != nil {
+			t.Errorf("%d: %v", n, err)
+		}
+	}
+}
+
+func BenchmarkUint128_DivPow10(b *testing.B) {
+	v := Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(v.DivPow10(18, ToNearestEven))
+	}
+}
+
+func TestInt128_DivPow10Quick(t *testing.T) {
+	for _, mode := range roundingModes {
+		mode := mode
+		f := func(l uint8, a Int128, n uint8) Int128 {
+			a = a.Rsh(uint(l % 128))
+			return a.DivPow10(uint(n%48), mode)
+		}
+		g := func(l uint8, a Int128, n uint8) Int128 {
+			a = a.Rsh(uint(l % 128))
+			bigA := int128ToBig(new(big.Int), a)
+			bigB := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n%48)), nil)
+			return bigToInt128(bigQuoRound(bigA, bigB, mode))
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+	}
+}
+
+func TestUint128_IsPowerOfTwo(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		want bool
+	}{
+		{Uint128{0, 0}, false},
+		{Uint128{0, 1}, true},
+		{Uint128{0, 2}, true},
+		{Uint128{0, 3}, false},
+		{Uint128{0, 0x8000_0000_0000_0000}, true},
+		{Uint128{1, 0}, true},
+		{Uint128{1, 1}, false},
+		{Uint128{3, 0}, false},
+		{Uint128{0x8000_0000_0000_0000, 0}, true},
+		{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, false},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.IsPowerOfTwo()
+		if got != tc.want {
+			t.Errorf("%d: %#v.IsPowerOfTwo() should %t, but %t", i, tc.a, tc.want, got)
+		}
+	}
+}
+
+func TestUint128_NextPowerOfTwo(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		want Uint128
+		ok   bool
+	}{
+		{Uint128{0, 0}, Uint128{0, 1}, true},
+		{Uint128{0, 1}, Uint128{0, 1}, true},
+		{Uint128{0, 2}, Uint128{0, 2}, true},
+		{Uint128{0, 3}, Uint128{0, 4}, true},
+		{Uint128{0, 0x8000_0000_0000_0001}, Uint128{1, 0}, true},
+		{Uint128{1, 0}, Uint128{1, 0}, true},
+		{Uint128{1, 1}, Uint128{2, 0}, true},
+		{Uint128{0x8000_0000_0000_0000, 0}, Uint128{0x8000_0000_0000_0000, 0}, true},
+		{Uint128{0x8000_0000_0000_0000, 1}, Uint128{0, 0}, false},
+	}
+
+	for i, tc := range testCases {
+		got, ok := tc.a.NextPowerOfTwo()
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%d: %#v.NextPowerOfTwo() should (%#v, %t), but (%#v, %t)", i, tc.a, tc.want, tc.ok, got, ok)
+		}
+	}
+}
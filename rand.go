@@ -0,0 +1,139 @@
+package int128
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// RandUint128 returns a uniformly random Uint128 read from r.
+// r is typically [crypto/rand.Reader].
+func RandUint128(r io.Reader) (Uint128, error) {
+	var buf [16]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return Uint128{}, err
+	}
+	return Uint128{binary.BigEndian.Uint64(buf[:8]), binary.BigEndian.Uint64(buf[8:])}, nil
+}
+
+// RandUint128N returns a uniformly random Uint128 in the half-open interval [0, n) read from r.
+// It panics if n == 0.
+func RandUint128N(r io.Reader, n Uint128) (Uint128, error) {
+	if n.H == 0 && n.L == 0 {
+		panic("int128: invalid argument to RandUint128N")
+	}
+	return uniform(func() (Uint128, error) { return RandUint128(r) }, n)
+}
+
+// RandInt128N returns a uniformly random Int128 in the half-open interval [0, n) read from r.
+// It panics if n <= 0.
+func RandInt128N(r io.Reader, n Int128) (Int128, error) {
+	if n.Sign() <= 0 {
+		panic("int128: invalid argument to RandInt128N")
+	}
+	v, err := uniform(func() (Uint128, error) { return RandUint128(r) }, n.Uint128())
+	return v.Int128(), err
+}
+
+// RandInt128Range returns a uniformly random Int128 in the half-open interval [min, max) read from r.
+// It panics if min >= max.
+func RandInt128Range(r io.Reader, min, max Int128) (Int128, error) {
+	if min.Cmp(max) >= 0 {
+		panic("int128: invalid argument to RandInt128Range")
+	}
+	// max - min doesn't fit in Int128, but fits in Uint128.
+	v, err := uniform(func() (Uint128, error) { return RandUint128(r) }, max.Sub(min).Uint128())
+	return min.Add(v.Int128()), err
+}
+
+// uniform returns a uniformly random integer in [0, n) using the random numbers from next.
+// n must not be zero.
+//
+// It uses Lemire's method: the upper half of the 256-bit product of a random number and n
+// is a random number in [0, n), and the lower half tells whether it is biased.
+// See https://arxiv.org/abs/1805.10941
+func uniform(next func() (Uint128, error), n Uint128) (Uint128, error) {
+	x, err := next()
+	if err != nil {
+		return Uint128{}, err
+	}
+	hi, lo := mul128(x, n)
+	if lo.Cmp(n) < 0 {
+		// thresh = 2**128 mod n
+		thresh := n.Neg().Mod(n)
+		for lo.Cmp(thresh) < 0 {
+			x, err = next()
+			if err != nil {
+				return Uint128{}, err
+			}
+			hi, lo = mul128(x, n)
+		}
+	}
+	return hi, nil
+}
+
+// Source is a source of uniformly-distributed pseudo-random uint64 values.
+// The sources of [math/rand/v2], such as *rand.PCG and *rand.ChaCha8, implement Source,
+// and so does *rand.Rand of [math/rand].
+type Source interface {
+	Uint64() uint64
+}
+
+// Rand is a source of random Uint128 and Int128 values
+// built on top of a Source.
+// Rand is not safe for concurrent use by multiple goroutines
+// unless the Source is.
+type Rand struct {
+	src Source
+}
+
+// NewRand returns a new Rand that uses random values from src.
+func NewRand(src Source) *Rand {
+	return &Rand{src: src}
+}
+
+// Uint128 returns a uniformly random Uint128.
+// It takes two values from the source; the first one is the upper half.
+func (r *Rand) Uint128() Uint128 {
+	h := r.src.Uint64()
+	l := r.src.Uint64()
+	return Uint128{h, l}
+}
+
+// Int128 returns a uniformly random Int128, including negative values.
+func (r *Rand) Int128() Int128 {
+	return r.Uint128().Int128()
+}
+
+// Uint128N returns a uniformly random Uint128 in the half-open interval [0, n).
+// It panics if n == 0.
+func (r *Rand) Uint128N(n Uint128) Uint128 {
+	if n.H == 0 && n.L == 0 {
+		panic("int128: invalid argument to Uint128N")
+	}
+	v, _ := uniform(r.next, n)
+	return v
+}
+
+// Int128N returns a uniformly random Int128 in the half-open interval [0, n).
+// It panics if n <= 0.
+func (r *Rand) Int128N(n Int128) Int128 {
+	if n.Sign() <= 0 {
+		panic("int128: invalid argument to Int128N")
+	}
+	v, _ := uniform(r.next, n.Uint128())
+	return v.Int128()
+}
+
+// Int128Range returns a uniformly random Int128 in the half-open interval [min, max).
+// It panics if min >= max.
+func (r *Rand) Int128Range(min, max Int128) Int128 {
+	if min.Cmp(max) >= 0 {
+		panic("int128: invalid argument to Int128Range")
+	}
+	v, _ := uniform(r.next, max.Sub(min).Uint128())
+	return min.Add(v.Int128())
+}
+
+func (r *Rand) next() (Uint128, error) {
+	return r.Uint128(), nil
+}
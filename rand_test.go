@@ -0,0 +1,148 @@
+package int128
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+var _ Source = (*rand.Rand)(nil)
+
+func TestRandUint128(t *testing.T) {
+	r := bytes.NewReader([]byte{
+		0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef,
+		0xfe, 0xdc, 0xba, 0x98, 0x76, 0x54, 0x32, 0x10,
+	})
+	got, err := RandUint128(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Uint128{0x0123_4567_89ab_cdef, 0xfedc_ba98_7654_3210}); got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	if _, err := RandUint128(r); !errors.Is(err, io.EOF) {
+		t.Errorf("want io.EOF, got %v", err)
+	}
+}
+
+func TestRandUint128N_Rejection(t *testing.T) {
+	// n = 3 * 2**126, so 2**128 mod n = 2**126.
+	// the first value 0 is rejected because the lower half of 0*n is less than 2**126,
+	// and the second value 1 is accepted.
+	n := Uint128{0xc000_0000_0000_0000, 0}
+	r := bytes.NewReader(append(make([]byte, 16), []byte{
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 1,
+	}...))
+	got, err := RandUint128N(r, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Uint128{}); got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+	if r.Len() != 0 {
+		t.Errorf("the first value should be rejected, but %d bytes are left", r.Len())
+	}
+}
+
+func TestRandUint128N_Quick(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	f := func(n Uint128) bool {
+		if n.H == 0 && n.L == 0 {
+			return true
+		}
+		v, err := RandUint128N(r, n)
+		return err == nil && v.Cmp(n) < 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRandInt128Range_Quick(t *testing.T) {
+	r := NewRand(rand.New(rand.NewSource(1)))
+	f := func(min, max Int128) bool {
+		if c := min.Cmp(max); c == 0 {
+			return true
+		} else if c > 0 {
+			min, max = max, min
+		}
+		v := r.Int128Range(min, max)
+		return min.Cmp(v) <= 0 && v.Cmp(max) < 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRand_Uint128N_Distribution(t *testing.T) {
+	const buckets = 6
+	const count = 60000
+	r := NewRand(rand.New(rand.NewSource(42)))
+
+	var hist [buckets]int
+	for i := 0; i < count; i++ {
+		v := r.Uint128N(Uint128{0, buckets})
+		hist[v.L]++
+	}
+	for i, c := range hist {
+		// about 5 sigma
+		if c < count/buckets-500 || c > count/buckets+500 {
+			t.Errorf("%d: the distribution is biased: %v", i, hist)
+		}
+	}
+
+	// the upper bit of the values in [0, 2**127+2**126) must be set in 1/3 of them.
+	n := Uint128{0xc000_0000_0000_0000, 0}
+	upper := 0
+	for i := 0; i < count; i++ {
+		if r.Uint128N(n).H>>63 != 0 {
+			upper++
+		}
+	}
+	if upper < count/3-600 || upper > count/3+600 {
+		t.Errorf("the distribution is biased: %d / %d", upper, count)
+	}
+}
+
+func TestRand_Int128N(t *testing.T) {
+	r := NewRand(rand.New(rand.NewSource(1)))
+	n := Int128{0, 10}
+	for i := 0; i < 1000; i++ {
+		v := r.Int128N(n)
+		if v.Sign() < 0 || v.Cmp(n) >= 0 {
+			t.Fatalf("%#v is out of range", v)
+		}
+	}
+
+	// (2**128-1) * 10 = 9 * 2**128 + (2**128 - 10)
+	v, err := RandInt128N(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)), n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Int128{0, 9}); v != want {
+		t.Errorf("want %#v, got %#v", want, v)
+	}
+}
+
+func TestRandUint128N_Panic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic, but not")
+		}
+	}()
+	_, _ = RandUint128N(bytes.NewReader(nil), Uint128{})
+}
+
+func BenchmarkRand_Uint128N(b *testing.B) {
+	r := NewRand(rand.New(rand.NewSource(1)))
+	n := Uint128{0xc000_0000_0000_0000, 0}
+	for i := 0; i < b.N; i++ {
+		r.Uint128N(n)
+	}
+}
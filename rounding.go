@@ -0,0 +1,66 @@
+package int128
+
+// RoundingMode determines how a quotient is rounded to an integer.
+// The modes are the same as the ones of [math/big.RoundingMode].
+type RoundingMode byte
+
+// These constants define supported rounding modes.
+const (
+	ToNearestEven RoundingMode = iota // == IEEE 754-2008 roundTiesToEven
+	ToNearestAway                     // == IEEE 754-2008 roundTiesToAway
+	ToZero                            // == IEEE 754-2008 roundTowardZero
+	AwayFromZero                      // no IEEE 754-2008 equivalent
+	ToNegativeInf                     // == IEEE 754-2008 roundTowardNegative
+	ToPositiveInf                     // == IEEE 754-2008 roundTowardPositive
+)
+
+// String returns the name of the rounding mode.
+func (mode RoundingMode) String() string {
+	switch mode {
+	case ToNearestEven:
+		return "ToNearestEven"
+	case ToNearestAway:
+		return "ToNearestAway"
+	case ToZero:
+		return "ToZero"
+	case AwayFromZero:
+		return "AwayFromZero"
+	case ToNegativeInf:
+		return "ToNegativeInf"
+	case ToPositiveInf:
+		return "ToPositiveInf"
+	}
+	return "RoundingMode(" + Uint128{0, uint64(mode)}.String() + ")"
+}
+
+// roundUp reports whether the magnitude of the quotient q must be incremented,
+// where r is the remainder of the division by d (0 <= r < d) and
+// neg is the sign of the exact quotient.
+func (mode RoundingMode) roundUp(q, r, d Uint128, neg bool) bool {
+	if r.H == 0 && r.L == 0 {
+		return false
+	}
+	switch mode {
+	case ToNearestEven, ToNearestAway:
+		// compare r with d/2 without overflow
+		switch r.Cmp(d.Sub(r)) {
+		case 1:
+			return true
+		case -1:
+			return false
+		}
+		if mode == ToNearestAway {
+			return true
+		}
+		return q.L&1 != 0
+	case ToZero:
+		return false
+	case AwayFromZero:
+		return true
+	case ToNegativeInf:
+		return neg
+	case ToPositiveInf:
+		return !neg
+	}
+	panic("int128: invalid rounding mode")
+}
@@ -0,0 +1,110 @@
+package int128
+
+import (
+	"math/big"
+	"testing"
+)
+
+var roundingModes = []RoundingMode{
+	ToNearestEven,
+	ToNearestAway,
+	ToZero,
+	AwayFromZero,
+	ToNegativeInf,
+	ToPositiveInf,
+}
+
+// bigQuoRound returns the quotient a/b rounded according to mode.
+func bigQuoRound(a, b *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	neg := a.Sign()*b.Sign() < 0
+	var up bool
+	switch mode {
+	case ToNearestEven, ToNearestAway:
+		r2 := new(big.Int).Abs(r)
+		r2.Lsh(r2, 1)
+		switch r2.CmpAbs(b) {
+		case 1:
+			up = true
+		case 0:
+			up = mode == ToNearestAway || q.Bit(0) != 0
+		}
+	case ToZero:
+	case AwayFromZero:
+		up = true
+	case ToNegativeInf:
+		up = neg
+	case ToPositiveInf:
+		up = !neg
+	}
+	if up {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// ratRound returns x rounded to an integer according to mode.
+func ratRound(x *big.Rat, mode RoundingMode) *big.Int {
+	// floor(x) and the fractional part of x
+	floor := new(big.Int).Div(x.Num(), x.Denom())
+	frac := new(big.Rat).Sub(x, new(big.Rat).SetInt(floor))
+	if frac.Sign() == 0 {
+		return floor
+	}
+
+	var up bool // round toward positive infinity
+	switch mode {
+	case ToNearestEven, ToNearestAway:
+		switch frac.Cmp(big.NewRat(1, 2)) {
+		case 1:
+			up = true
+		case 0:
+			if mode == ToNearestEven {
+				up = floor.Bit(0) != 0
+			} else {
+				up = x.Sign() > 0
+			}
+		}
+	case ToZero:
+		up = x.Sign() < 0
+	case AwayFromZero:
+		up = x.Sign() > 0
+	case ToNegativeInf:
+		up = false
+	case ToPositiveInf:
+		up = true
+	}
+	if up {
+		floor.Add(floor, big.NewInt(1))
+	}
+	return floor
+}
+
+func TestRoundingMode_String(t *testing.T) {
+	testCases := []struct {
+		mode RoundingMode
+		want string
+	}{
+		{ToNearestEven, "ToNearestEven"},
+		{ToNearestAway, "ToNearestAway"},
+		{ToZero, "ToZero"},
+		{AwayFromZero, "AwayFromZero"},
+		{ToNegativeInf, "ToNegativeInf"},
+		{ToPositiveInf, "ToPositiveInf"},
+		{RoundingMode(42), "RoundingMode(42)"},
+	}
+
+	for _, tc := range testCases {
+		got := tc.mode.String()
+		if got != tc.want {
+			t.Errorf("want %q, got %q", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package int128
+
+import "math/bits"
+
+// SumUint128s returns the sum of s, and reports whether the sum fits in Uint128.
+// If the sum overflows, the returned value wraps around.
+func SumUint128s(s []Uint128) (Uint128, bool) {
+	// two independent accumulators break the dependency chain of the carries.
+	var h0, l0, h1, l1, overflow uint64
+	for len(s) >= 4 {
+		var c0, c1 uint64
+		l0, c0 = bits.Add64(l0, s[0].L, 0)
+		h0, c0 = bits.Add64(h0, s[0].H, c0)
+		l1, c1 = bits.Add64(l1, s[1].L, 0)
+		h1, c1 = bits.Add64(h1, s[1].H, c1)
+		overflow |= c0 | c1
+		l0, c0 = bits.Add64(l0, s[2].L, 0)
+		h0, c0 = bits.Add64(h0, s[2].H, c0)
+		l1, c1 = bits.Add64(l1, s[3].L, 0)
+		h1, c1 = bits.Add64(h1, s[3].H, c1)
+		overflow |= c0 | c1
+		s = s[4:]
+	}
+	for _, v := range s {
+		var c uint64
+		l0, c = bits.Add64(l0, v.L, 0)
+		h0, c = bits.Add64(h0, v.H, c)
+		overflow |= c
+	}
+
+	var c uint64
+	l0, c = bits.Add64(l0, l1, 0)
+	h0, c = bits.Add64(h0, h1, c)
+	overflow |= c
+	return Uint128{h0, l0}, overflow == 0
+}
+
+// SumInt128s returns the sum of s, and reports whether the sum fits in Int128.
+// If the sum overflows, the returned value wraps around.
+// Intermediate overflows that cancel each other out don't make the sum overflow.
+func SumInt128s(s []Int128) (Int128, bool) {
+	// the accumulators are 192-bit integers (e, h, l),
+	// so they never overflow in practice.
+	var h0, l0, h1, l1 uint64
+	var e0, e1 int64
+	for len(s) >= 4 {
+		var c0, c1 uint64
+		l0, c0 = bits.Add64(l0, s[0].L, 0)
+		h0, c0 = bits.Add64(h0, uint64(s[0].H), c0)
+		e0 += int64(c0) + s[0].H>>63
+		l1, c1 = bits.Add64(l1, s[1].L, 0)
+		h1, c1 = bits.Add64(h1, uint64(s[1].H), c1)
+		e1 += int64(c1) + s[1].H>>63
+		l0, c0 = bits.Add64(l0, s[2].L, 0)
+		h0, c0 = bits.Add64(h0, uint64(s[2].H), c0)
+		e0 += int64(c0) + s[2].H>>63
+		l1, c1 = bits.Add64(l1, s[3].L, 0)
+		h1, c1 = bits.Add64(h1, uint64(s[3].H), c1)
+		e1 += int64(c1) + s[3].H>>63
+		s = s[4:]
+	}
+	for _, v := range s {
+		var c uint64
+		l0, c = bits.Add64(l0, v.L, 0)
+		h0, c = bits.Add64(h0, uint64(v.H), c)
+		e0 += int64(c) + v.H>>63
+	}
+
+	var c uint64
+	l0, c = bits.Add64(l0, l1, 0)
+	h0, c = bits.Add64(h0, h1, c)
+	e0 += e1 + int64(c)
+
+	// the sum fits in Int128 if the extension word is the sign extension of h0.
+	return Int128{int64(h0), l0}, e0 == int64(h0)>>63
+}
+
+// AddUint128s sets dst[i] = a[i] + b[i] for each i, and reports whether no element overflows.
+// The elements that overflow wrap around.
+// AddUint128s panics if len(a) != len(b) or len(dst) < len(a).
+func AddUint128s(dst, a, b []Uint128) bool {
+	if len(a) != len(b) || len(dst) < len(a) {
+		panic("int128: AddUint128s length mismatch")
+	}
+	var overflow uint64
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	for i := range a {
+		var c uint64
+		dst[i], c = addUint128(a[i], b[i])
+		overflow |= c
+	}
+	return overflow == 0
+}
+
+// addUint128 returns a+b and the carry out.
+func addUint128(a, b Uint128) (Uint128, uint64) {
+	l, c := bits.Add64(a.L, b.L, 0)
+	h, c := bits.Add64(a.H, b.H, c)
+	return Uint128{h, l}, c
+}
+
+// AddInt128s sets dst[i] = a[i] + b[i] for each i, and reports whether no element overflows.
+// The elements that overflow wrap around.
+// AddInt128s panics if len(a) != len(b) or len(dst) < len(a).
+func AddInt128s(dst, a, b []Int128) bool {
+	if len(a) != len(b) || len(dst) < len(a) {
+		panic("int128: AddInt128s length mismatch")
+	}
+	// the sign bit of overflow is set if any element overflows.
+	var overflow int64
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	for i := range a {
+		var o int64
+		dst[i], o = addInt128(a[i], b[i])
+		overflow |= o
+	}
+	return overflow >= 0
+}
+
+// addInt128 returns a+b and the overflow word.
+// The sign bit of the overflow word is set if a+b overflows.
+func addInt128(a, b Int128) (Int128, int64) {
+	l, c := bits.Add64(a.L, b.L, 0)
+	h, _ := bits.Add64(uint64(a.H), uint64(b.H), c)
+	// overflow occurs if a and b have the same sign and the sign of the sum differs.
+	return Int128{int64(h), l}, (a.H ^ int64(h)) & (b.H ^ int64(h))
+}
+
+// MulScalarUint128s sets dst[i] = a[i] * k for each i, and reports whether no element overflows.
+// The elements that overflow wrap around.
+// MulScalarUint128s panics if len(dst) < len(a).
+func MulScalarUint128s(dst, a []Uint128, k Uint128) bool {
+	if len(dst) < len(a) {
+		panic("int128: MulScalarUint128s length mismatch")
+	}
+	dst = dst[:len(a)]
+	ok := true
+	for i, v := range a {
+		var o bool
+		dst[i], o = mulOverflow(v, k)
+		ok = ok && o
+	}
+	return ok
+}
+
+// MulScalarInt128s sets dst[i] = a[i] * k for each i, and reports whether no element overflows.
+// The elements that overflow wrap around.
+// MulScalarInt128s panics if len(dst) < len(a).
+func MulScalarInt128s(dst, a []Int128, k Int128) bool {
+	if len(dst) < len(a) {
+		panic("int128: MulScalarInt128s length mismatch")
+	}
+	dst = dst[:len(a)]
+	negK, absK := k.IsNeg(), k.AbsUint128()
+	ok := true
+	for i, v := range a {
+		mag, o := mulOverflow(v.AbsUint128(), absK)
+		var fit bool
+		dst[i], fit = Int128FromSignMagnitude(v.IsNeg() != negK, mag)
+		ok = ok && o && fit
+	}
+	return ok
+}
+
+// MinMaxUint128s returns the smallest and the largest values of s.
+// MinMaxUint128s panics if s is empty.
+func MinMaxUint128s(s []Uint128) (Uint128, Uint128) {
+	if len(s) == 0 {
+		panic("int128: MinMaxUint128s of empty slice")
+	}
+	min, max := s[0], s[0]
+	s = s[1:]
+
+	// compare the elements in pairs:
+	// it needs 3 comparisons per 2 elements instead of 4.
+	for len(s) >= 2 {
+		x, y := s[0], s[1]
+		if lessUint128(y, x) {
+			x, y = y, x
+		}
+		if lessUint128(x, min) {
+			min = x
+		}
+		if lessUint128(max, y) {
+			max = y
+		}
+		s = s[2:]
+	}
+	if len(s) > 0 {
+		if lessUint128(s[0], min) {
+			min = s[0]
+		}
+		if lessUint128(max, s[0]) {
+			max = s[0]
+		}
+	}
+	return min, max
+}
+
+// MinMaxInt128s returns the smallest and the largest values of s.
+// MinMaxInt128s panics if s is empty.
+func MinMaxInt128s(s []Int128) (Int128, Int128) {
+	if len(s) == 0 {
+		panic("int128: MinMaxInt128s of empty slice")
+	}
+
+	// flipping the sign bits maps the order of Int128 to the order of Uint128.
+	min, max := s[0].Uint128(), s[0].Uint128()
+	min.H ^= 1 << 63
+	max.H ^= 1 << 63
+	s = s[1:]
+
+	for len(s) >= 2 {
+		x, y := s[0].Uint128(), s[1].Uint128()
+		x.H ^= 1 << 63
+		y.H ^= 1 << 63
+		if lessUint128(y, x) {
+			x, y = y, x
+		}
+		if lessUint128(x, min) {
+			min = x
+		}
+		if lessUint128(max, y) {
+			max = y
+		}
+		s = s[2:]
+	}
+	if len(s) > 0 {
+		x := s[0].Uint128()
+		x.H ^= 1 << 63
+		if lessUint128(x, min) {
+			min = x
+		}
+		if lessUint128(max, x) {
+			max = x
+		}
+	}
+	min.H ^= 1 << 63
+	max.H ^= 1 << 63
+	return min.Int128(), max.Int128()
+}
+
+// lessUint128 reports whether a < b.
+func lessUint128(a, b Uint128) bool {
+	_, borrow := bits.Sub64(a.L, b.L, 0)
+	_, borrow = bits.Sub64(a.H, b.H, borrow)
+	return borrow != 0
+}
+
+// CompareSlices compares the elements of a and b in lexicographic order.
+// The elements are compared sequentially, starting at index 0,
+// until one element is not equal to the other.
+// The result of comparing the first non-matching elements is returned.
+// If both slices are equal until one of them ends, the shorter slice is
+// considered less than the longer one.
+// The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+func CompareSlices[T Integer[T]](a, b []T) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := a[i].Cmp(b[i]); c != 0 {
+			return c
+		}
+	}
+	if len(a) < len(b) {
+		return -1
+	}
+	if len(a) > len(b) {
+		return 1
+	}
+	return 0
+}
+
+// CountIf returns the number of elements of s that satisfy pred.
+func CountIf[T any](s []T, pred func(T) bool) int {
+	n := 0
+	for _, v := range s {
+		if pred(v) {
+			n++
+		}
+	}
+	return n
+}
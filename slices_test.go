@@ -0,0 +1,424 @@
+package int128
+
+import (
+	"math"
+	"math/big"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+func TestSumUint128s(t *testing.T) {
+	max := Uint128{math.MaxUint64, math.MaxUint64}
+	testCases := []struct {
+		s    []Uint128
+		sum  Uint128
+		fits bool
+	}{
+		{nil, Uint128{}, true},
+		{[]Uint128{{0, 1}}, Uint128{0, 1}, true},
+		{[]Uint128{{0, math.MaxUint64}, {0, 1}}, Uint128{1, 0}, true},
+		{[]Uint128{max, {0, 1}}, Uint128{}, false},
+		{[]Uint128{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}}, Uint128{0, 15}, true},
+		{[]Uint128{{0, 1}, {0, 2}, {0, 3}, max}, Uint128{0, 5}, false},
+	}
+
+	for i, tc := range testCases {
+		sum, fits := SumUint128s(tc.s)
+		if sum != tc.sum || fits != tc.fits {
+			t.Errorf("%d: SumUint128s(%v) should (%d, %t), but (%d, %t)", i, tc.s, tc.sum, tc.fits, sum, fits)
+		}
+	}
+}
+
+func TestSumUint128sQuick(t *testing.T) {
+	f := func(s []Uint128, shift uint8) (Uint128, bool) {
+		t := make([]Uint128, len(s))
+		for i := range s {
+			// make the overflows less likely.
+			t[i] = s[i].Rsh(uint(shift % 128))
+		}
+		return SumUint128s(t)
+	}
+	g := func(s []Uint128, shift uint8) (Uint128, bool) {
+		sum := new(big.Int)
+		for _, v := range s {
+			sum.Add(sum, uint128ToBig(nil, v.Rsh(uint(shift%128))))
+		}
+		ret := bigToUint128(sum)
+		return ret, uint128ToBig(nil, ret).Cmp(sum) == 0
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSumInt128s(t *testing.T) {
+	max := Int128{math.MaxInt64, math.MaxUint64}
+	min := Int128{math.MinInt64, 0}
+	testCases := []struct {
+		s    []Int128
+		sum  Int128
+		fits bool
+	}{
+		{nil, Int128{}, true},
+		{[]Int128{{-1, math.MaxUint64}}, Int128{-1, math.MaxUint64}, true},
+		{[]Int128{max, {0, 1}}, min, false},
+		{[]Int128{min, {-1, math.MaxUint64}}, max, false},
+
+		// the intermediate overflows cancel each other out.
+		{[]Int128{max, {0, 1}, {-1, math.MaxUint64}}, max, true},
+		{[]Int128{max, max, min, min, {0, 1}}, Int128{-1, math.MaxUint64}, true},
+	}
+
+	for i, tc := range testCases {
+		sum, fits := SumInt128s(tc.s)
+		if sum != tc.sum || fits != tc.fits {
+			t.Errorf("%d: SumInt128s(%v) should (%d, %t), but (%d, %t)", i, tc.s, tc.sum, tc.fits, sum, fits)
+		}
+	}
+}
+
+func TestSumInt128sQuick(t *testing.T) {
+	f := func(s []Int128, shift uint8) (Int128, bool) {
+		t := make([]Int128, len(s))
+		for i := range s {
+			// make the overflows less likely.
+			t[i] = s[i].Rsh(uint(shift % 128))
+		}
+		return SumInt128s(t)
+	}
+	g := func(s []Int128, shift uint8) (Int128, bool) {
+		sum := new(big.Int)
+		for _, v := range s {
+			sum.Add(sum, int128ToBig(nil, v.Rsh(uint(shift%128))))
+		}
+		ret := bigToInt128(sum)
+		return ret, int128ToBig(nil, ret).Cmp(sum) == 0
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddSlicesQuick(t *testing.T) {
+	t.Run("Uint128", func(t *testing.T) {
+		f := func(a, b []Uint128) ([]Uint128, bool) {
+			b = resizeUint128s(b, len(a))
+			dst := make([]Uint128, len(a))
+			ok := AddUint128s(dst, a, b)
+			return dst, ok
+		}
+		g := func(a, b []Uint128) ([]Uint128, bool) {
+			b = resizeUint128s(b, len(a))
+			dst := make([]Uint128, len(a))
+			ok := true
+			for i := range a {
+				dst[i] = a[i].Add(b[i])
+				ok = ok && dst[i].Cmp(a[i]) >= 0
+			}
+			return dst, ok
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Int128", func(t *testing.T) {
+		f := func(a, b []Int128, shift uint8) ([]Int128, bool) {
+			b = resizeInt128s(b, len(a))
+			c := make([]Int128, len(b))
+			for i := range b {
+				// make the overflows less likely.
+				c[i] = b[i].Rsh(uint(shift % 128))
+			}
+			dst := make([]Int128, len(a))
+			ok := AddInt128s(dst, a, c)
+			return dst, ok
+		}
+		g := func(a, b []Int128, shift uint8) ([]Int128, bool) {
+			b = resizeInt128s(b, len(a))
+			dst := make([]Int128, len(a))
+			ok := true
+			for i := range a {
+				b := b[i].Rsh(uint(shift % 128))
+				dst[i] = a[i].Add(b)
+				sum := new(big.Int).Add(int128ToBig(nil, a[i]), int128ToBig(nil, b))
+				ok = ok && int128ToBig(nil, dst[i]).Cmp(sum) == 0
+			}
+			return dst, ok
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestAddSlices_LengthMismatch(t *testing.T) {
+	t.Run("Uint128", func(t *testing.T) {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Error("AddUint128s should panic")
+			}
+		}()
+		AddUint128s(make([]Uint128, 1), make([]Uint128, 2), make([]Uint128, 2))
+	})
+
+	t.Run("Int128", func(t *testing.T) {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Error("AddInt128s should panic")
+			}
+		}()
+		AddInt128s(make([]Int128, 2), make([]Int128, 2), make([]Int128, 1))
+	})
+}
+
+func TestMulScalarQuick(t *testing.T) {
+	t.Run("Uint128", func(t *testing.T) {
+		f := func(a []Uint128, k Uint128, shift uint8) ([]Uint128, bool) {
+			k = k.Rsh(uint(shift % 128))
+			dst := make([]Uint128, len(a))
+			ok := MulScalarUint128s(dst, a, k)
+			return dst, ok
+		}
+		g := func(a []Uint128, k Uint128, shift uint8) ([]Uint128, bool) {
+			k = k.Rsh(uint(shift % 128))
+			dst := make([]Uint128, len(a))
+			ok := true
+			for i := range a {
+				dst[i] = a[i].Mul(k)
+				mul := new(big.Int).Mul(uint128ToBig(nil, a[i]), uint128ToBig(nil, k))
+				ok = ok && uint128ToBig(nil, dst[i]).Cmp(mul) == 0
+			}
+			return dst, ok
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Int128", func(t *testing.T) {
+		f := func(a []Int128, k Int128, shift uint8) ([]Int128, bool) {
+			k = k.Rsh(uint(shift % 128))
+			dst := make([]Int128, len(a))
+			ok := MulScalarInt128s(dst, a, k)
+			return dst, ok
+		}
+		g := func(a []Int128, k Int128, shift uint8) ([]Int128, bool) {
+			k = k.Rsh(uint(shift % 128))
+			dst := make([]Int128, len(a))
+			ok := true
+			for i := range a {
+				dst[i] = a[i].Mul(k)
+				mul := new(big.Int).Mul(int128ToBig(nil, a[i]), int128ToBig(nil, k))
+				ok = ok && int128ToBig(nil, dst[i]).Cmp(mul) == 0
+			}
+			return dst, ok
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestMinMaxQuick(t *testing.T) {
+	t.Run("Uint128", func(t *testing.T) {
+		f := func(x Uint128, rest []Uint128) [2]Uint128 {
+			min, max := MinMaxUint128s(append([]Uint128{x}, rest...))
+			return [2]Uint128{min, max}
+		}
+		g := func(x Uint128, rest []Uint128) [2]Uint128 {
+			return [2]Uint128{Min(x, rest...), Max(x, rest...)}
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Int128", func(t *testing.T) {
+		f := func(x Int128, rest []Int128) [2]Int128 {
+			min, max := MinMaxInt128s(append([]Int128{x}, rest...))
+			return [2]Int128{min, max}
+		}
+		g := func(x Int128, rest []Int128) [2]Int128 {
+			return [2]Int128{Min(x, rest...), Max(x, rest...)}
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestMinMax_Empty(t *testing.T) {
+	t.Run("Uint128", func(t *testing.T) {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Error("MinMaxUint128s should panic")
+			}
+		}()
+		MinMaxUint128s([]Uint128{})
+	})
+
+	t.Run("Int128", func(t *testing.T) {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Error("MinMaxInt128s should panic")
+			}
+		}()
+		MinMaxInt128s([]Int128{})
+	})
+}
+
+func TestCompareSlices(t *testing.T) {
+	testCases := []struct {
+		a, b []Int128
+		want int
+	}{
+		{nil, nil, 0},
+		{nil, []Int128{{0, 1}}, -1},
+		{[]Int128{{0, 1}}, nil, 1},
+		{[]Int128{{0, 1}, {0, 2}}, []Int128{{0, 1}, {0, 2}}, 0},
+		{[]Int128{{0, 1}, {-1, 0}}, []Int128{{0, 1}, {0, 2}}, -1},
+		{[]Int128{{0, 1}, {0, 3}}, []Int128{{0, 1}, {0, 2}, {0, 3}}, 1},
+		{[]Int128{{0, 1}, {0, 2}}, []Int128{{0, 1}, {0, 2}, {0, 3}}, -1},
+	}
+
+	for i, tc := range testCases {
+		got := CompareSlices(tc.a, tc.b)
+		if got != tc.want {
+			t.Errorf("%d: CompareSlices(%v, %v) should %d, but %d", i, tc.a, tc.b, tc.want, got)
+		}
+
+		// the unsigned comparison is the same unless the signs differ.
+		if i == 4 {
+			continue
+		}
+		a, b := make([]Uint128, len(tc.a)), make([]Uint128, len(tc.b))
+		for j, v := range tc.a {
+			a[j] = v.Uint128()
+		}
+		for j, v := range tc.b {
+			b[j] = v.Uint128()
+		}
+		got = CompareSlices(a, b)
+		if got != tc.want {
+			t.Errorf("%d: CompareSlices(%v, %v) should %d, but %d", i, a, b, tc.want, got)
+		}
+	}
+}
+
+func TestCompareSlices_Builtin(t *testing.T) {
+	// CompareSlices accepts any Integer type.
+	a := []Builtin[int]{{1}, {-2}}
+	b := []Builtin[int]{{1}, {3}}
+	if got := CompareSlices(a, b); got != -1 {
+		t.Errorf("CompareSlices(%v, %v) should -1, but %d", a, b, got)
+	}
+}
+
+func TestCountIf(t *testing.T) {
+	s := []Int128{{0, 1}, {-1, 0}, {0, 2}, {-1, math.MaxUint64}, {0, 0}}
+	got := CountIf(s, Int128.IsNeg)
+	if got != 2 {
+		t.Errorf("CountIf(%v, IsNeg) should 2, but %d", s, got)
+	}
+}
+
+func resizeUint128s(s []Uint128, n int) []Uint128 {
+	for len(s) < n {
+		s = append(s, Uint128{0, uint64(len(s))})
+	}
+	return s[:n]
+}
+
+func resizeInt128s(s []Int128, n int) []Int128 {
+	for len(s) < n {
+		s = append(s, Int128{0, uint64(len(s))})
+	}
+	return s[:n]
+}
+
+var benchmarkUint128s = func() []Uint128 {
+	s := make([]Uint128, 256)
+	for i := range s {
+		s[i] = Uint128{uint64(i), uint64(i) * 0x9e37_79b9_7f4a_7c15}
+	}
+	return s
+}()
+
+func BenchmarkSumUint128s(b *testing.B) {
+	b.Run("SumUint128s", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum, ok := SumUint128s(benchmarkUint128s)
+			runtime.KeepAlive(sum)
+			runtime.KeepAlive(ok)
+		}
+	})
+
+	b.Run("Sum", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			runtime.KeepAlive(Sum(benchmarkUint128s...))
+		}
+	})
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var sum Uint128
+			for _, v := range benchmarkUint128s {
+				sum = sum.Add(v)
+			}
+			runtime.KeepAlive(sum)
+		}
+	})
+}
+
+func BenchmarkAddUint128s(b *testing.B) {
+	dst := make([]Uint128, len(benchmarkUint128s))
+	b.Run("AddUint128s", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			runtime.KeepAlive(AddUint128s(dst, benchmarkUint128s, benchmarkUint128s))
+		}
+	})
+
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range benchmarkUint128s {
+				dst[j] = benchmarkUint128s[j].Add(benchmarkUint128s[j])
+			}
+		}
+	})
+}
+
+func BenchmarkMinMaxUint128s(b *testing.B) {
+	b.Run("MinMaxUint128s", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			min, max := MinMaxUint128s(benchmarkUint128s)
+			runtime.KeepAlive(min)
+			runtime.KeepAlive(max)
+		}
+	})
+
+	b.Run("Min and Max", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			runtime.KeepAlive(Min(benchmarkUint128s[0], benchmarkUint128s[1:]...))
+			runtime.KeepAlive(Max(benchmarkUint128s[0], benchmarkUint128s[1:]...))
+		}
+	})
+}
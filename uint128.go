@@ -3,6 +3,8 @@ package int128
 import (
 	"math"
 	"math/bits"
+
+	"github.com/shogo82148/int128/internal/arith"
 )
 
 // Uint128 is a 128-bit unsigned integer.
@@ -40,59 +42,33 @@ func (a Uint128) Mul(b Uint128) Uint128 {
 	return Uint128{h + h1 + h2, l}
 }
 
+// mul128 returns the 256-bit product of x and y: (hi, lo) = x * y.
+//
+// This function's execution time does not depend on the inputs.
+func mul128(x, y Uint128) (hi, lo Uint128) {
+	h, l := arith.Mul128(arith.Uint128(x), arith.Uint128(y))
+	return Uint128(h), Uint128(l)
+}
+
+// div128 returns the quotient and remainder of (hi, lo) divided by y.
+// div128 panics for y == 0 (division by zero) or y <= hi (quotient overflow).
+func div128(hi, lo, y Uint128) (quo, rem Uint128) {
+	q, r := arith.Div128(arith.Uint128(hi), arith.Uint128(lo), arith.Uint128(y))
+	return Uint128(q), Uint128(r)
+}
+
 // Div returns the quotient a/b for b != 0.
 // If b == 0, a division-by-zero run-time panic occurs.
 func (a Uint128) Div(b Uint128) Uint128 {
-	if b.H == 0 {
-		// optimize for uint128 / uint64
-		h := a.H / b.L
-		l, _ := bits.Div64(a.H%b.L, a.L, b.L)
-		return Uint128{h, l}
-	}
-
-	n := uint(bits.LeadingZeros64(b.H))
-	x := a.Rsh(1)
-	y := b.Lsh(n)
-	q, _ := bits.Div64(x.H, x.L, y.H)
-	q >>= 63 - n
-	if q > 0 {
-		q--
-	}
-
-	h, l := bits.Mul64(b.L, q)
-	h += b.H * q
-	r := a.Sub(Uint128{h, l})
-	if r.Cmp(b) >= 0 {
-		q++
-	}
-	return Uint128{0, q}
+	qH, qL, _, _ := divmod128(a.H, a.L, b.H, b.L)
+	return Uint128{qH, qL}
 }
 
 // Mod returns the modulus x%y for y != 0.
 // If y == 0, a division-by-zero run-time panic occurs.
 func (a Uint128) Mod(b Uint128) Uint128 {
-	if b.H == 0 {
-		// optimize for uint128 / uint64
-		_, rem := bits.Div64(a.H%b.L, a.L, b.L)
-		return Uint128{0, rem}
-	}
-
-	n := uint(bits.LeadingZeros64(b.H))
-	x := a.Rsh(1)
-	y := b.Lsh(n)
-	q, _ := bits.Div64(x.H, x.L, y.H)
-	q >>= 63 - n
-	if q > 0 {
-		q--
-	}
-
-	h, l := bits.Mul64(b.L, q)
-	h += b.H * q
-	r := a.Sub(Uint128{h, l})
-	if r.Cmp(b) >= 0 {
-		r = r.Sub(b)
-	}
-	return r
+	_, _, rH, rL := divmod128(a.H, a.L, b.H, b.L)
+	return Uint128{rH, rL}
 }
 
 // DivMod returns the quotient and remainder of a/b for b != 0.
@@ -100,31 +76,11 @@ func (a Uint128) Mod(b Uint128) Uint128 {
 //
 //	q = a div b  such that
 //	m = a - b*q  with 0 <= m < |y|
+//
+// On amd64, DivMod is implemented in assembly with the DIVQ instruction.
 func (a Uint128) DivMod(b Uint128) (Uint128, Uint128) {
-	if b.H == 0 {
-		// optimize for uint128 / uint64
-		h := a.H / b.L
-		l, rem := bits.Div64(a.H%b.L, a.L, b.L)
-		return Uint128{h, l}, Uint128{0, rem}
-	}
-
-	n := uint(bits.LeadingZeros64(b.H))
-	x := a.Rsh(1)
-	y := b.Lsh(n)
-	q, _ := bits.Div64(x.H, x.L, y.H)
-	q >>= 63 - n
-	if q > 0 {
-		q--
-	}
-
-	h, l := bits.Mul64(b.L, q)
-	h += b.H * q
-	r := a.Sub(Uint128{h, l})
-	if r.Cmp(b) >= 0 {
-		q++
-		r = r.Sub(b)
-	}
-	return Uint128{0, q}, r
+	qH, qL, rH, rL := divmod128(a.H, a.L, b.H, b.L)
+	return Uint128{qH, qL}, Uint128{rH, rL}
 }
 
 // Quo returns the quotient a/b for b != 0.
@@ -152,6 +108,33 @@ func (a Uint128) QuoRem(b Uint128) (Uint128, Uint128) {
 	return a.DivMod(b)
 }
 
+// DivFloor returns the quotient a/b rounded toward negative infinity for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// DivFloor is the same as Div in Uint128.
+func (a Uint128) DivFloor(b Uint128) Uint128 {
+	return a.Div(b)
+}
+
+// DivCeil returns the quotient a/b rounded toward positive infinity for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+func (a Uint128) DivCeil(b Uint128) Uint128 {
+	q, r := a.DivMod(b)
+	if r.H != 0 || r.L != 0 {
+		q = q.Add(Uint128{0, 1})
+	}
+	return q
+}
+
+// DivRound returns the quotient a/b rounded according to mode for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+func (a Uint128) DivRound(b Uint128, mode RoundingMode) Uint128 {
+	q, r := a.DivMod(b)
+	if mode.roundUp(q, r, b, false) {
+		q = q.Add(Uint128{0, 1})
+	}
+	return q
+}
+
 // Cmp compares a and b and returns:
 //
 //	-1 if a <  b
@@ -339,8 +322,8 @@ func (a Uint128) Text(base int) string {
 
 // Append appends the string representation of a, as generated by a.Text(base), to buf and returns the extended buffer.
 func (a Uint128) Append(dst []byte, base int) []byte {
-	if base == 10 && a.H == 0 && a.L < nSmalls {
-		return append(dst, small(int(a.L))...)
+	if base == 10 {
+		return a.AppendDecimal(dst)
 	}
 	d, _ := formatUint128(dst, a.H, a.L, base, false, true)
 	return d
@@ -351,8 +334,9 @@ func (a Uint128) String() string {
 	if a.H == 0 && a.L < nSmalls {
 		return small(int(a.L))
 	}
-	_, s := formatUint128(nil, a.H, a.L, 10, false, false)
-	return s
+	var buf [maxDecimalLen]byte
+	i := putDecimal(buf[:], a.H, a.L)
+	return string(buf[i:])
 }
 
 const nSmalls = 100
@@ -392,35 +376,8 @@ func formatUint128(dst []byte, h, l uint64, base int, neg bool, append_ bool) ([
 	}
 
 	if base == 10 {
-		// common case: use constants for / because
-		// the compiler can optimize it into a multiply+shift
-		for h != 0 {
-			var r uint64
-			l, r = bits.Div64(h%1e18, l, 1e18)
-			h /= 1e18
-			for j := 0; j < 9; j++ {
-				is := (r % 100) * 2
-				r /= 100
-				i -= 2
-				s[i+1] = smallsString[is+1]
-				s[i+0] = smallsString[is+0]
-			}
-		}
-		for l >= 100 {
-			is := (l % 100) * 2
-			l /= 100
-			i -= 2
-			s[i+1] = smallsString[is+1]
-			s[i+0] = smallsString[is+0]
-		}
-
-		if l >= 10 {
-			i--
-			s[i] = digits[l%10]
-			l /= 10
-		}
-		i--
-		s[i] = digits[l]
+		// common case: split into 19-digit chunks
+		i = putDecimal(s[:], h, l)
 	} else if isPowerOfTwo(base) {
 		// Use shifts and masks instead of / and %.
 		shift := uint(bits.TrailingZeros(uint(base))) & 7
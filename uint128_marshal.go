@@ -1,5 +1,12 @@
 package int128
 
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+)
+
 func (a Uint128) MarshalText() ([]byte, error) {
 	text := a.Append(nil, 10)
 	return text, nil
@@ -9,3 +16,100 @@ func (a Uint128) MarshalJSON() ([]byte, error) {
 	text := a.Append(nil, 10)
 	return text, nil
 }
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+// It accepts the decimal representation of a, such as "12345".
+func (a *Uint128) UnmarshalText(text []byte) error {
+	v, err := parseUint128(text)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// UnmarshalJSON implements [encoding/json.Unmarshaler].
+// It accepts both a JSON number and a JSON string.
+func (a *Uint128) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	return a.UnmarshalText(data)
+}
+
+// MarshalXML implements [encoding/xml.Marshaler].
+// a is encoded as the decimal representation in the element.
+func (a Uint128) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(a.String(), start)
+}
+
+// UnmarshalXML implements [encoding/xml.Unmarshaler].
+// The leading and trailing spaces of the element are ignored.
+func (a *Uint128) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return a.UnmarshalText([]byte(strings.TrimSpace(s)))
+}
+
+// MarshalXMLAttr implements [encoding/xml.MarshalerAttr].
+func (a Uint128) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: a.String()}, nil
+}
+
+// UnmarshalXMLAttr implements [encoding/xml.UnmarshalerAttr].
+func (a *Uint128) UnmarshalXMLAttr(attr xml.Attr) error {
+	return a.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v2 and gopkg.in/yaml.v3.
+// a is encoded as an integer if it fits in uint64,
+// and as a decimal string otherwise.
+func (a Uint128) MarshalYAML() (interface{}, error) {
+	if a.H == 0 {
+		return a.L, nil
+	}
+	return a.String(), nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of gopkg.in/yaml.v2,
+// which gopkg.in/yaml.v3 also supports.
+// It decodes the scalar as a string so that large integers don't lose precision by float64.
+func (a *Uint128) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return a.UnmarshalText([]byte(s))
+}
+
+// MarshalTOML implements the Marshaler interface of github.com/BurntSushi/toml.
+// a is encoded as an integer if it fits in int64, which is the range of TOML integers,
+// and as a decimal string otherwise.
+func (a Uint128) MarshalTOML() ([]byte, error) {
+	if a.H == 0 && a.L <= math.MaxInt64 {
+		return a.Append(nil, 10), nil
+	}
+	text := append([]byte{'"'}, a.Append(nil, 10)...)
+	return append(text, '"'), nil
+}
+
+// UnmarshalTOML implements the Unmarshaler interface of github.com/BurntSushi/toml.
+// It accepts both a TOML integer and a TOML string.
+func (a *Uint128) UnmarshalTOML(v interface{}) error {
+	switch v := v.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("int128: parsing %d: %w", v, ErrRange)
+		}
+		*a = Uint128{0, uint64(v)}
+		return nil
+	case string:
+		return a.UnmarshalText([]byte(v))
+	}
+	return fmt.Errorf("int128: cannot unmarshal %T into Uint128", v)
+}
@@ -3,7 +3,14 @@ package int128
 import (
 	"encoding"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"strconv"
 	"testing"
+	"testing/quick"
 )
 
 var _ = json.Marshaler(Uint128{})
@@ -19,3 +26,288 @@ func TestUint128_MarshalJSON(t *testing.T) {
 		t.Errorf("want %q, got %q", "12345", string(data))
 	}
 }
+
+var _ = json.Unmarshaler(&Uint128{})
+var _ = encoding.TextUnmarshaler(&Uint128{})
+var _ = xml.Marshaler(Uint128{})
+var _ = xml.Unmarshaler(&Uint128{})
+var _ = xml.MarshalerAttr(Uint128{})
+var _ = xml.UnmarshalerAttr(&Uint128{})
+var _ = yamlMarshaler(Uint128{})
+var _ = yamlUnmarshaler(&Uint128{})
+var _ = tomlMarshaler(Uint128{})
+var _ = tomlUnmarshaler(&Uint128{})
+
+// the local copies of the interfaces of gopkg.in/yaml.v2 and github.com/BurntSushi/toml.
+
+type yamlMarshaler interface {
+	MarshalYAML() (interface{}, error)
+}
+
+type yamlUnmarshaler interface {
+	UnmarshalYAML(unmarshal func(interface{}) error) error
+}
+
+type tomlMarshaler interface {
+	MarshalTOML() ([]byte, error)
+}
+
+type tomlUnmarshaler interface {
+	UnmarshalTOML(interface{}) error
+}
+
+// yamlRoundTrip emulates the YAML encoder and decoder.
+// The encoder writes the value returned by MarshalYAML as a scalar,
+// and the decoder decodes the scalar into the target type of unmarshal.
+// Like gopkg.in/yaml.v3, it decodes numbers into interface{} as float64 if they overflow int64 and uint64.
+func yamlRoundTrip(t *testing.T, m yamlMarshaler, u yamlUnmarshaler) error {
+	t.Helper()
+	v, err := m.MarshalYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scalar := fmt.Sprint(v)
+	return u.UnmarshalYAML(func(out interface{}) error {
+		switch out := out.(type) {
+		case *string:
+			*out = scalar
+		case *interface{}:
+			if i, err := strconv.ParseInt(scalar, 10, 64); err == nil {
+				*out = i
+			} else if u, err := strconv.ParseUint(scalar, 10, 64); err == nil {
+				*out = u
+			} else if f, err := strconv.ParseFloat(scalar, 64); err == nil {
+				*out = f
+			} else {
+				*out = scalar
+			}
+		default:
+			return fmt.Errorf("unsupported type %T", out)
+		}
+		return nil
+	})
+}
+
+// tomlRoundTrip emulates the TOML encoder and decoder of github.com/BurntSushi/toml.
+// The encoder writes the bytes returned by MarshalTOML as is,
+// and the decoder passes the TOML integers as int64 and the TOML strings as string to UnmarshalTOML.
+func tomlRoundTrip(t *testing.T, m tomlMarshaler, u tomlUnmarshaler) (string, error) {
+	t.Helper()
+	data, err := m.MarshalTOML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s, u.UnmarshalTOML(s[1 : len(s)-1])
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		// it is not a valid TOML integer.
+		return s, err
+	}
+	return s, u.UnmarshalTOML(i)
+}
+
+func TestUint128_UnmarshalText(t *testing.T) {
+	testCases := []struct {
+		text string
+		want Uint128
+		err  error
+	}{
+		{"0", Uint128{0, 0}, nil},
+		{"12345", Uint128{0, 12345}, nil},
+		{"00012345", Uint128{0, 12345}, nil},
+		{"18446744073709551615", Uint128{0, math.MaxUint64}, nil},
+		{"18446744073709551616", Uint128{1, 0}, nil},
+		{"10000000000000000000", Uint128{0, 10000000000000000000}, nil},
+		{"340282366920938463463374607431768211455", Uint128{math.MaxUint64, math.MaxUint64}, nil},
+		{"0000000000000000000000000000000000000000000000000000340282366920938463463374607431768211455", Uint128{math.MaxUint64, math.MaxUint64}, nil},
+		{"340282366920938463463374607431768211456", Uint128{}, ErrRange},
+		{"1000000000000000000000000000000000000000", Uint128{}, ErrRange},
+		{"", Uint128{}, ErrSyntax},
+		{"+1", Uint128{}, ErrSyntax},
+		{"-1", Uint128{}, ErrSyntax},
+		{"-0", Uint128{}, ErrSyntax},
+		{"1_000", Uint128{}, ErrSyntax},
+		{"0x10", Uint128{}, ErrSyntax},
+		{"1.0", Uint128{}, ErrSyntax},
+		{" 1", Uint128{}, ErrSyntax},
+	}
+
+	for i, tc := range testCases {
+		var a Uint128
+		err := a.UnmarshalText([]byte(tc.text))
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%d: %q should be error %v, but %v", i, tc.text, tc.err, err)
+			continue
+		}
+		if a != tc.want {
+			t.Errorf("%d: %q should %#v, but %#v", i, tc.text, tc.want, a)
+		}
+	}
+}
+
+func TestUint128_UnmarshalTextQuick(t *testing.T) {
+	f := func(a Uint128, shift uint8) bool {
+		a = a.Rsh(uint(shift % 128))
+		var b Uint128
+		if err := b.UnmarshalText([]byte(a.String())); err != nil {
+			t.Error(err)
+			return false
+		}
+		return a == b
+	}
+	if err := quick.Check(f, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		json string
+		want Uint128
+	}{
+		{`0`, Uint128{0, 0}},
+		{`12345`, Uint128{0, 12345}},
+		{`"12345"`, Uint128{0, 12345}},
+		{`340282366920938463463374607431768211455`, Uint128{math.MaxUint64, math.MaxUint64}},
+		{`"340282366920938463463374607431768211455"`, Uint128{math.MaxUint64, math.MaxUint64}},
+	}
+	for i, tc := range testCases {
+		var a Uint128
+		if err := json.Unmarshal([]byte(tc.json), &a); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if a != tc.want {
+			t.Errorf("%d: %s should %#v, but %#v", i, tc.json, tc.want, a)
+		}
+	}
+
+	// null is no-op.
+	a := Uint128{0, 42}
+	if err := json.Unmarshal([]byte(`null`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if a != (Uint128{0, 42}) {
+		t.Errorf("null should keep the value, but %#v", a)
+	}
+
+	for _, s := range []string{`-1`, `1.5`, `1e3`, `""`, `true`, `{}`, `340282366920938463463374607431768211456`} {
+		var a Uint128
+		if err := json.Unmarshal([]byte(s), &a); err == nil {
+			t.Errorf("%s: want error, got nil", s)
+		}
+	}
+}
+
+func TestUint128_XML(t *testing.T) {
+	type Account struct {
+		XMLName xml.Name `xml:"account"`
+		ID      Uint128  `xml:"id,attr"`
+		Balance Uint128  `xml:"balance"`
+	}
+	v := Account{
+		ID:      Uint128{math.MaxUint64, math.MaxUint64},
+		Balance: Uint128{1, 0},
+	}
+	data, err := xml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<account id="340282366920938463463374607431768211455"><balance>18446744073709551616</balance></account>`
+	if string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+
+	var got Account
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != v.ID || got.Balance != v.Balance {
+		t.Errorf("want %#v, got %#v", v, got)
+	}
+
+	// the spaces around the values are ignored.
+	data = []byte("<account id=\" 1 \">\n  <balance>\n    2\n  </balance>\n</account>")
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != (Uint128{0, 1}) || got.Balance != (Uint128{0, 2}) {
+		t.Errorf("unexpected result: %#v", got)
+	}
+
+	for _, s := range []string{`<account id="-1"></account>`, `<account><balance>x</balance></account>`} {
+		var got Account
+		if err := xml.Unmarshal([]byte(s), &got); err == nil {
+			t.Errorf("%s: want error, got nil", s)
+		}
+	}
+}
+
+func TestUint128_YAML(t *testing.T) {
+	testCases := []Uint128{
+		{0, 0},
+		{0, math.MaxUint64},
+		{1, 0},
+		{math.MaxUint64, math.MaxUint64},
+	}
+	for i, tc := range testCases {
+		var got Uint128
+		if err := yamlRoundTrip(t, tc, &got); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if got != tc {
+			t.Errorf("%d: want %#v, got %#v", i, tc, got)
+		}
+	}
+}
+
+func TestUint128_TOML(t *testing.T) {
+	testCases := []struct {
+		a    Uint128
+		toml string
+	}{
+		{Uint128{0, 0}, `0`},
+		{Uint128{0, math.MaxInt64}, `9223372036854775807`},
+		{Uint128{0, 1 << 63}, `"9223372036854775808"`},
+		{Uint128{math.MaxUint64, math.MaxUint64}, `"340282366920938463463374607431768211455"`},
+	}
+	for i, tc := range testCases {
+		var got Uint128
+		s, err := tomlRoundTrip(t, tc.a, &got)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if s != tc.toml {
+			t.Errorf("%d: %#v should be encoded into %s, but %s", i, tc.a, tc.toml, s)
+		}
+		if got != tc.a {
+			t.Errorf("%d: want %#v, got %#v", i, tc.a, got)
+		}
+	}
+
+	var a Uint128
+	if err := a.UnmarshalTOML(int64(-1)); !errors.Is(err, ErrRange) {
+		t.Errorf("want %v, got %v", ErrRange, err)
+	}
+	if err := a.UnmarshalTOML(1.5); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func BenchmarkUint128_UnmarshalText(b *testing.B) {
+	text := []byte("340282366920938463463374607431768211455")
+	var a Uint128
+	for i := 0; i < b.N; i++ {
+		if err := a.UnmarshalText(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+	runtime.KeepAlive(a)
+}
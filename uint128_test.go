@@ -255,6 +255,128 @@ func BenchmarkUint128_Mul(b *testing.B) {
 	}
 }
 
+func TestMul128Quick(t *testing.T) {
+	f := func(a, b Uint128) (Uint128, Uint128) {
+		return mul128(a, b)
+	}
+	g := func(a, b Uint128) (Uint128, Uint128) {
+		bigA := uint128ToBig(new(big.Int), a)
+		bigB := uint128ToBig(new(big.Int), b)
+		bigA.Mul(bigA, bigB)
+		return bigToUint128(new(big.Int).Rsh(bigA, 128)), bigToUint128(bigA)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkMul128(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hi, lo := mul128(uint128Input, uint128Input)
+		runtime.KeepAlive(hi)
+		runtime.KeepAlive(lo)
+	}
+}
+
+func TestDiv128(t *testing.T) {
+	testCases := []struct {
+		hi, lo, y, quo, rem Uint128
+	}{
+		{
+			Uint128{0, 0},
+			Uint128{0, 7},
+			Uint128{0, 2},
+			Uint128{0, 3},
+			Uint128{0, 1},
+		},
+		{
+			Uint128{0, 1},
+			Uint128{0, 0},
+			Uint128{0, 2},
+			Uint128{0x8000_0000_0000_0000, 0},
+			Uint128{0, 0},
+		},
+		{
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_fffe},
+			Uint128{0, 1},
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0, 0},
+		},
+		{
+			Uint128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0x8000_0000_0000_0000, 0},
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0x7fff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+		},
+	}
+
+	for i, tc := range testCases {
+		quo, rem := div128(tc.hi, tc.lo, tc.y)
+		if quo != tc.quo || rem != tc.rem {
+			t.Errorf("%d: (%#v, %#v) / %#v should (%#v, %#v), but (%#v, %#v)", i, tc.hi, tc.lo, tc.y, tc.quo, tc.rem, quo, rem)
+		}
+	}
+}
+
+func TestDiv128Quick(t *testing.T) {
+	f := func(l uint8, hi, lo, y Uint128) (Uint128, Uint128) {
+		y.H |= 1 << 63
+		y = y.Rsh(uint(l % 128))
+		hi = hi.Mod(y)
+		return div128(hi, lo, y)
+	}
+	g := func(l uint8, hi, lo, y Uint128) (Uint128, Uint128) {
+		y.H |= 1 << 63
+		y = y.Rsh(uint(l % 128))
+		hi = hi.Mod(y)
+		bigX := uint128ToBig(new(big.Int), hi)
+		bigX.Lsh(bigX, 128)
+		bigX.Or(bigX, uint128ToBig(new(big.Int), lo))
+		bigY := uint128ToBig(new(big.Int), y)
+		quo, rem := new(big.Int).QuoRem(bigX, bigY, new(big.Int))
+		return bigToUint128(quo), bigToUint128(rem)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 10000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDiv128Panic(t *testing.T) {
+	testCases := []struct {
+		hi, lo, y Uint128
+	}{
+		{Uint128{0, 0}, Uint128{0, 1}, Uint128{0, 0}},
+		{Uint128{0, 1}, Uint128{0, 0}, Uint128{0, 1}},
+		{Uint128{1, 0}, Uint128{0, 0}, Uint128{0, 0xffff_ffff_ffff_ffff}},
+	}
+
+	for i, tc := range testCases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%d: want panic, but not", i)
+				}
+			}()
+			div128(tc.hi, tc.lo, tc.y)
+		}()
+	}
+}
+
+func BenchmarkDiv128(b *testing.B) {
+	y := Uint128{0x1234_5678_9abc_def0, 0x1234_5678_9abc_def0}
+	for i := 0; i < b.N; i++ {
+		quo, rem := div128(uint128Input, uint128Input, y)
+		runtime.KeepAlive(quo)
+		runtime.KeepAlive(rem)
+	}
+}
+
 func TestUint128_DivMod(t *testing.T) {
 	testCases := []struct {
 		a, b, div, mod Uint128
@@ -338,6 +460,28 @@ func BenchmarkUint128_DivMod(b *testing.B) {
 	}
 }
 
+func BenchmarkUint128_DivModLarge(b *testing.B) {
+	// the divisor doesn't fit in uint64.
+	x := Uint128{0x1234_5678_9abc_def0, 0x1234_5678_9abc_def0}
+	y := Uint128{0x1234, 0x5678_9abc_def0_1234}
+	for i := 0; i < b.N; i++ {
+		div, mod := x.DivMod(y)
+		runtime.KeepAlive(div)
+		runtime.KeepAlive(mod)
+	}
+}
+
+func BenchmarkUint128_DivModBy64(b *testing.B) {
+	// the divisor fits in uint64, but the quotient doesn't.
+	x := Uint128{0x1234_5678_9abc_def0, 0x1234_5678_9abc_def0}
+	y := Uint128{0, 0x5678_9abc_def0_1234}
+	for i := 0; i < b.N; i++ {
+		div, mod := x.DivMod(y)
+		runtime.KeepAlive(div)
+		runtime.KeepAlive(mod)
+	}
+}
+
 func BenchmarkUint128_Div(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		runtime.KeepAlive(uint128Input.Div(uint128Input))
@@ -350,6 +494,94 @@ func BenchmarkUint128_Mod(b *testing.B) {
 	}
 }
 
+func TestUint128_DivRound(t *testing.T) {
+	testCases := []struct {
+		a, b Uint128
+		mode RoundingMode
+		want Uint128
+	}{
+		{Uint128{0, 7}, Uint128{0, 2}, ToNearestEven, Uint128{0, 4}},
+		{Uint128{0, 5}, Uint128{0, 2}, ToNearestEven, Uint128{0, 2}},
+		{Uint128{0, 5}, Uint128{0, 2}, ToNearestAway, Uint128{0, 3}},
+		{Uint128{0, 5}, Uint128{0, 3}, ToNearestEven, Uint128{0, 2}},
+		{Uint128{0, 4}, Uint128{0, 3}, ToNearestEven, Uint128{0, 1}},
+		{Uint128{0, 4}, Uint128{0, 3}, ToZero, Uint128{0, 1}},
+		{Uint128{0, 4}, Uint128{0, 3}, AwayFromZero, Uint128{0, 2}},
+		{Uint128{0, 4}, Uint128{0, 3}, ToNegativeInf, Uint128{0, 1}},
+		{Uint128{0, 4}, Uint128{0, 3}, ToPositiveInf, Uint128{0, 2}},
+		{Uint128{0, 6}, Uint128{0, 3}, ToPositiveInf, Uint128{0, 2}},
+		{
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0x8000_0000_0000_0000, 0},
+			ToNearestEven,
+			Uint128{0, 2},
+		},
+		{
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff},
+			ToPositiveInf,
+			Uint128{0, 1},
+		},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.DivRound(tc.b, tc.mode)
+		if got != tc.want {
+			t.Errorf("%d: %#v / %#v (%s) should %#v, but %#v", i, tc.a, tc.b, tc.mode, tc.want, got)
+		}
+	}
+}
+
+func TestUint128_DivRoundQuick(t *testing.T) {
+	for _, mode := range roundingModes {
+		mode := mode
+		f := func(l uint, a, b Uint128) Uint128 {
+			b.H |= 1 << 63
+			b = b.Rsh(l % 128)
+			return a.DivRound(b, mode)
+		}
+		g := func(l uint, a, b Uint128) Uint128 {
+			b.H |= 1 << 63
+			b = b.Rsh(l % 128)
+			bigA := uint128ToBig(new(big.Int), a)
+			bigB := uint128ToBig(new(big.Int), b)
+			return bigToUint128(ratRound(new(big.Rat).SetFrac(bigA, bigB), mode))
+		}
+		if err := quick.CheckEqual(f, g, &quick.Config{
+			MaxCountScale: 1000,
+		}); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+	}
+}
+
+func TestUint128_DivFloorCeilQuick(t *testing.T) {
+	f := func(l uint, a, b Uint128) (Uint128, Uint128) {
+		b.H |= 1 << 63
+		b = b.Rsh(l % 128)
+		return a.DivFloor(b), a.DivCeil(b)
+	}
+	g := func(l uint, a, b Uint128) (Uint128, Uint128) {
+		b.H |= 1 << 63
+		b = b.Rsh(l % 128)
+		bigA := uint128ToBig(new(big.Int), a)
+		bigB := uint128ToBig(new(big.Int), b)
+		x := new(big.Rat).SetFrac(bigA, bigB)
+		return bigToUint128(ratRound(x, ToNegativeInf)), bigToUint128(ratRound(x, ToPositiveInf))
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 1000,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkUint128_DivRound(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint128Input.DivRound(uint128Input, ToNearestEven))
+	}
+}
+
 func TestUint128_Cmp(t *testing.T) {
 	testCases := []struct {
 		a, b Uint128
@@ -0,0 +1,354 @@
+package int128
+
+import (
+	"math/bits"
+)
+
+// Uint256 is a 256-bit unsigned integer.
+type Uint256 struct {
+	H Uint128
+	L Uint128
+}
+
+// Add returns the sum a+b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Add(b Uint256) Uint256 {
+	var carry uint64
+	var ret Uint256
+	ret.L.L, carry = bits.Add64(a.L.L, b.L.L, 0)
+	ret.L.H, carry = bits.Add64(a.L.H, b.L.H, carry)
+	ret.H.L, carry = bits.Add64(a.H.L, b.H.L, carry)
+	ret.H.H, _ = bits.Add64(a.H.H, b.H.H, carry)
+	return ret
+}
+
+// Sub returns the difference x-y.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Sub(b Uint256) Uint256 {
+	var borrow uint64
+	var ret Uint256
+	ret.L.L, borrow = bits.Sub64(a.L.L, b.L.L, 0)
+	ret.L.H, borrow = bits.Sub64(a.L.H, b.L.H, borrow)
+	ret.H.L, borrow = bits.Sub64(a.H.L, b.H.L, borrow)
+	ret.H.H, _ = bits.Sub64(a.H.H, b.H.H, borrow)
+	return ret
+}
+
+// Mul returns the product x*y.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Mul(b Uint256) Uint256 {
+	h, l := mul128(a.L, b.L)
+	h1 := a.H.Mul(b.L)
+	h2 := a.L.Mul(b.H)
+	return Uint256{h.Add(h1).Add(h2), l}
+}
+
+// Div returns the quotient a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+func (a Uint256) Div(b Uint256) Uint256 {
+	q, _ := a.DivMod(b)
+	return q
+}
+
+// Mod returns the modulus x%y for y != 0.
+// If y == 0, a division-by-zero run-time panic occurs.
+func (a Uint256) Mod(b Uint256) Uint256 {
+	_, r := a.DivMod(b)
+	return r
+}
+
+// DivMod returns the quotient and remainder of a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+//
+//	q = a div b  such that
+//	m = a - b*q  with 0 <= m < |y|
+func (a Uint256) DivMod(b Uint256) (Uint256, Uint256) {
+	if b.H.H == 0 && b.H.L == 0 {
+		// optimize for uint256 / uint128
+		h, r := a.H.DivMod(b.L)
+		l, rem := div128(r, a.L, b.L)
+		return Uint256{h, l}, Uint256{Uint128{}, rem}
+	}
+
+	n := uint(b.H.LeadingZeros())
+	x := a.Rsh(1)
+	y := b.Lsh(n)
+	q, _ := div128(x.H, x.L, y.H)
+	q = q.Rsh(127 - n)
+	if q.H != 0 || q.L != 0 {
+		q = q.Sub(Uint128{0, 1})
+	}
+
+	r := a.Sub(b.Mul(Uint256{Uint128{}, q}))
+	if r.Cmp(b) >= 0 {
+		q = q.Add(Uint128{0, 1})
+		r = r.Sub(b)
+	}
+	return Uint256{Uint128{}, q}, r
+}
+
+// Quo returns the quotient a/b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// Quo is the same as Div in Uint256.
+func (a Uint256) Quo(b Uint256) Uint256 {
+	return a.Div(b)
+}
+
+// Rem returns he remainder a%b for b != 0.
+// If b == 0, a division-by-zero run-time panic occurs.
+// Rem is the same as Mod in Uint256.
+func (a Uint256) Rem(b Uint256) Uint256 {
+	return a.Mod(b)
+}
+
+// QuoRem returns the quotient a/b and the remainder a%b for b != 0.
+// a division-by-zero run-time panic occurs.
+//
+//	q = a/b      with the result truncated to zero
+//	r = a - b*q
+//
+// QuoRem is the same as DivMod in Uint256.
+func (a Uint256) QuoRem(b Uint256) (Uint256, Uint256) {
+	return a.DivMod(b)
+}
+
+// Cmp compares a and b and returns:
+//
+//	-1 if a <  b
+//	 0 if a == b
+//	+1 if a >  b
+func (a Uint256) Cmp(b Uint256) int {
+	if c := a.H.Cmp(b.H); c != 0 {
+		return c
+	}
+	return a.L.Cmp(b.L)
+}
+
+// And returns the bitwise AND a&b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) And(b Uint256) Uint256 {
+	return Uint256{a.H.And(b.H), a.L.And(b.L)}
+}
+
+// Or returns the bitwise OR a|b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Or(b Uint256) Uint256 {
+	return Uint256{a.H.Or(b.H), a.L.Or(b.L)}
+}
+
+// Xor returns the bitwise XOR a^b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Xor(b Uint256) Uint256 {
+	return Uint256{a.H.Xor(b.H), a.L.Xor(b.L)}
+}
+
+// AndNot returns the bitwise AND NOT a&^b.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) AndNot(b Uint256) Uint256 {
+	return Uint256{a.H.AndNot(b.H), a.L.AndNot(b.L)}
+}
+
+// Not returns the bitwise NOT ^a.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Not() Uint256 {
+	return Uint256{a.H.Not(), a.L.Not()}
+}
+
+// Neg returns the negation -a.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Neg() Uint256 {
+	return Uint256{}.Sub(a)
+}
+
+// Lsh returns the logical left shift a<<i.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Lsh(i uint) Uint256 {
+	// This operation may overflow, but it's okay because when it overflows,
+	// the result is always greater than or equal to 128.
+	// And shifts of 128 bits or more always result in 0, so they don't affect the final result.
+	n := uint(i - 128)
+	m := uint(128 - i)
+
+	return Uint256{a.H.Lsh(i).Or(a.L.Lsh(n)).Or(a.L.Rsh(m)), a.L.Lsh(i)}
+}
+
+// Rsh returns the logical right shift a>>i.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) Rsh(i uint) Uint256 {
+	// This operation may overflow, but it's okay because when it overflows,
+	// the result is always greater than or equal to 128.
+	// And shifts of 128 bits or more always result in 0, so they don't affect the final result.
+	n := uint(i - 128)
+	m := uint(128 - i)
+
+	return Uint256{a.H.Rsh(i), a.H.Rsh(n).Or(a.H.Lsh(m)).Or(a.L.Rsh(i))}
+}
+
+// LeadingZeros returns the number of leading zero bits in a; the result is 256 for a == 0.
+func (a Uint256) LeadingZeros() int {
+	if a.H.H == 0 && a.H.L == 0 {
+		return 128 + a.L.LeadingZeros()
+	}
+	return a.H.LeadingZeros()
+}
+
+// TrailingZeros returns the number of trailing zero bits in a; the result is 256 for a == 0.
+func (a Uint256) TrailingZeros() int {
+	if a.L.H == 0 && a.L.L == 0 {
+		return 128 + a.H.TrailingZeros()
+	}
+	return a.L.TrailingZeros()
+}
+
+// Len returns the minimum number of bits required to represent a; the result is 0 for a == 0.
+func (a Uint256) Len() int {
+	if a.H.H == 0 && a.H.L == 0 {
+		return a.L.Len()
+	}
+	return 128 + a.H.Len()
+}
+
+// OnesCount returns the number of one bits ("population count") in a.
+func (a Uint256) OnesCount() int {
+	return a.H.OnesCount() + a.L.OnesCount()
+}
+
+// RotateLeft returns the value of a rotated left by (k mod 256) bits.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) RotateLeft(k int) Uint256 {
+	const n = 256
+	s := uint(k) & (n - 1)
+	return a.Lsh(s).Or(a.Rsh(n - s))
+}
+
+// Reverse returns the value of a with its bits in reversed order.
+func (a Uint256) Reverse() Uint256 {
+	return Uint256{a.L.Reverse(), a.H.Reverse()}
+}
+
+// ReverseBytes returns the value of a with its bytes in reversed order.
+//
+// This function's execution time does not depend on the inputs.
+func (a Uint256) ReverseBytes() Uint256 {
+	return Uint256{a.L.ReverseBytes(), a.H.ReverseBytes()}
+}
+
+// IsZero reports whether a is zero.
+func (a Uint256) IsZero() bool {
+	return a.H.H == 0 && a.H.L == 0 && a.L.H == 0 && a.L.L == 0
+}
+
+// Int256 returns a as a signed 256-bit integer.
+func (a Uint256) Int256() Int256 {
+	return Int256{a.H.Int128(), a.L}
+}
+
+// Uint256 returns a as an unsigned 256-bit integer.
+func (a Uint128) Uint256() Uint256 {
+	return Uint256{Uint128{}, a}
+}
+
+// MulUint256 returns the full 256-bit product a*b.
+func (a Uint128) MulUint256(b Uint128) Uint256 {
+	hi, lo := mul128(a, b)
+	return Uint256{hi, lo}
+}
+
+// Text returns the string representation of a in the given base.
+// Base must be between 2 and 36, inclusive.
+// The result uses the lower-case letters 'a' to 'z' for digit values 10 to 35.
+// No prefix (such as "0x") is added to the string.
+func (a Uint256) Text(base int) string {
+	_, s := formatUint256(nil, a, base, false, false)
+	return s
+}
+
+// Append appends the string representation of a, as generated by a.Text(base), to buf and returns the extended buffer.
+func (a Uint256) Append(dst []byte, base int) []byte {
+	d, _ := formatUint256(dst, a, base, false, true)
+	return d
+}
+
+// String returns the decimal representation of a as generated by a.Text(10).
+func (a Uint256) String() string {
+	_, s := formatUint256(nil, a, 10, false, false)
+	return s
+}
+
+// quoUint64 returns the quotient and the remainder of a/d.
+func (a Uint256) quoUint64(d uint64) (Uint256, uint64) {
+	var r uint64
+	a.H.H, r = bits.Div64(0, a.H.H, d)
+	a.H.L, r = bits.Div64(r, a.H.L, d)
+	a.L.H, r = bits.Div64(r, a.L.H, d)
+	a.L.L, r = bits.Div64(r, a.L.L, d)
+	return a, r
+}
+
+func formatUint256(dst []byte, a Uint256, base int, neg bool, append_ bool) ([]byte, string) {
+	if base < 2 || base > len(digits) {
+		panic("int128: illegal Append/Format base")
+	}
+
+	var s [256 + 1]byte // +1 is for the sign
+	i := len(s)
+
+	// find the largest power of base that fits in uint64.
+	b := uint64(base)
+	bigBase, n := b, 1
+	for bigBase <= (1<<64-1)/b {
+		bigBase *= b
+		n++
+	}
+
+	// convert n digits at a time.
+	for a.H.H != 0 || a.H.L != 0 || a.L.H != 0 {
+		var r uint64
+		a, r = a.quoUint64(bigBase)
+		if base == 10 {
+			i -= 19
+			putChunk(s[i:], r)
+			continue
+		}
+		for j := 0; j < n; j++ {
+			i--
+			q := r / b
+			s[i] = digits[uint(r-q*b)]
+			r = q
+		}
+	}
+
+	l := a.L.L
+	for l >= b {
+		i--
+		q := l / b
+		s[i] = digits[uint(l-q*b)]
+		l = q
+	}
+	// l < base
+	i--
+	s[i] = digits[uint(l)]
+
+	// add the sign
+	if neg {
+		i--
+		s[i] = '-'
+	}
+
+	if append_ {
+		return append(dst, s[i:]...), ""
+	}
+	return nil, string(s[i:])
+}
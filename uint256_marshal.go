@@ -0,0 +1,11 @@
+package int128
+
+func (a Uint256) MarshalText() ([]byte, error) {
+	text := a.Append(nil, 10)
+	return text, nil
+}
+
+func (a Uint256) MarshalJSON() ([]byte, error) {
+	text := a.Append(nil, 10)
+	return text, nil
+}
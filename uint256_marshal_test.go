@@ -0,0 +1,21 @@
+package int128
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+)
+
+var _ = json.Marshaler(Uint256{})
+var _ = encoding.TextMarshaler(Uint256{})
+
+func TestUint256_MarshalJSON(t *testing.T) {
+	a := Uint256{L: Uint128{0, 12345}}
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "12345" {
+		t.Errorf("want %q, got %q", "12345", string(data))
+	}
+}
@@ -0,0 +1,436 @@
+package int128
+
+import (
+	"math/big"
+	"runtime"
+	"testing"
+	"testing/quick"
+)
+
+// uint256Input is used for benchmarks to prevent compiler optimizations.
+var uint256Input = Uint256{Uint128{0, 42}, Uint128{0, 42}}
+
+var bigModUint256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// uint256ToBig converts x to a big.Int.
+func uint256ToBig(b *big.Int, x Uint256) *big.Int {
+	if b == nil {
+		b = new(big.Int)
+	}
+	uint128ToBig(b, x.H)
+	b.Lsh(b, 128)
+	return b.Or(b, uint128ToBig(nil, x.L))
+}
+
+// bigToUint256 converts x to a Uint256.
+func bigToUint256(x *big.Int) Uint256 {
+	z := new(big.Int).Mod(x, bigModUint256)
+	return Uint256{
+		H: bigToUint128(new(big.Int).Rsh(z, 128)),
+		L: bigToUint128(z),
+	}
+}
+
+func TestUint256_Add(t *testing.T) {
+	testCases := []struct {
+		a, b, want Uint256
+	}{
+		{
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+		},
+		{
+			Uint256{Uint128{0, 0}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+			Uint256{Uint128{0, 0}, Uint128{0, 1}},
+			Uint256{Uint128{0, 1}, Uint128{0, 0}},
+		},
+		{
+			Uint256{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+			Uint256{Uint128{0, 0}, Uint128{0, 1}},
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+		},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.Add(tc.b)
+		if got != tc.want {
+			t.Errorf("%d: %#v + %#v should %#v, but %#v", i, tc.a, tc.b, tc.want, got)
+		}
+	}
+}
+
+func TestUint256_AddQuick(t *testing.T) {
+	f := func(a, b Uint256) Uint256 {
+		return a.Add(b)
+	}
+	g := func(a, b Uint256) Uint256 {
+		bigA := uint256ToBig(new(big.Int), a)
+		bigB := uint256ToBig(new(big.Int), b)
+		bigA.Add(bigA, bigB)
+		return bigToUint256(bigA)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkUint256_Add(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint256Input.Add(uint256Input))
+	}
+}
+
+func TestUint256_Sub(t *testing.T) {
+	testCases := []struct {
+		a, b, want Uint256
+	}{
+		{
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+		},
+		{
+			Uint256{Uint128{0, 1}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0, 1}},
+			Uint256{Uint128{0, 0}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+		},
+		{
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0, 1}},
+			Uint256{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+		},
+	}
+
+	for i, tc := range testCases {
+		got := tc.a.Sub(tc.b)
+		if got != tc.want {
+			t.Errorf("%d: %#v - %#v should %#v, but %#v", i, tc.a, tc.b, tc.want, got)
+		}
+	}
+}
+
+func TestUint256_SubQuick(t *testing.T) {
+	f := func(a, b Uint256) Uint256 {
+		return a.Sub(b)
+	}
+	g := func(a, b Uint256) Uint256 {
+		bigA := uint256ToBig(new(big.Int), a)
+		bigB := uint256ToBig(new(big.Int), b)
+		bigA.Sub(bigA, bigB)
+		return bigToUint256(bigA)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkUint256_Sub(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint256Input.Sub(uint256Input))
+	}
+}
+
+func TestUint256_MulQuick(t *testing.T) {
+	f := func(a, b Uint256) Uint256 {
+		return a.Mul(b)
+	}
+	g := func(a, b Uint256) Uint256 {
+		bigA := uint256ToBig(new(big.Int), a)
+		bigB := uint256ToBig(new(big.Int), b)
+		bigA.Mul(bigA, bigB)
+		return bigToUint256(bigA)
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128_MulUint256Quick(t *testing.T) {
+	f := func(a, b Uint128) Uint256 {
+		return a.MulUint256(b)
+	}
+	g := func(a, b Uint128) Uint256 {
+		bigA := uint128ToBig(nil, a)
+		bigB := uint128ToBig(nil, b)
+		return bigToUint256(bigA.Mul(bigA, bigB))
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkUint256_Mul(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint256Input.Mul(uint256Input))
+	}
+}
+
+func TestUint256_DivMod(t *testing.T) {
+	testCases := []struct {
+		a, b, div, mod Uint256
+	}{
+		{
+			Uint256{Uint128{0, 0}, Uint128{0, 10}},
+			Uint256{Uint128{0, 0}, Uint128{0, 3}},
+			Uint256{Uint128{0, 0}, Uint128{0, 3}},
+			Uint256{Uint128{0, 0}, Uint128{0, 1}},
+		},
+		{
+			Uint256{Uint128{0, 1}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{1, 0}},
+			Uint256{Uint128{0, 0}, Uint128{1, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+		},
+		{
+			Uint256{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+			Uint256{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+			Uint256{Uint128{0, 0}, Uint128{0, 1}},
+			Uint256{Uint128{0, 0}, Uint128{0, 0}},
+		},
+		{
+			Uint256{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+			Uint256{Uint128{0, 1}, Uint128{0, 0}},
+			Uint256{Uint128{0, 0}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+			Uint256{Uint128{0, 0}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}},
+		},
+	}
+
+	for i, tc := range testCases {
+		div, mod := tc.a.DivMod(tc.b)
+		if div != tc.div {
+			t.Errorf("%d: %#v / %#v should %#v, but %#v", i, tc.a, tc.b, tc.div, div)
+		}
+		if mod != tc.mod {
+			t.Errorf("%d: %#v %% %#v should %#v, but %#v", i, tc.a, tc.b, tc.mod, mod)
+		}
+	}
+}
+
+func TestUint256_DivModQuick(t *testing.T) {
+	f := func(a, b Uint256) [2]Uint256 {
+		if b.IsZero() {
+			return [2]Uint256{}
+		}
+		div, mod := a.DivMod(b)
+		return [2]Uint256{div, mod}
+	}
+	g := func(a, b Uint256) [2]Uint256 {
+		if b.IsZero() {
+			return [2]Uint256{}
+		}
+		bigA := uint256ToBig(new(big.Int), a)
+		bigB := uint256ToBig(new(big.Int), b)
+		div, mod := new(big.Int).DivMod(bigA, bigB, new(big.Int))
+		return [2]Uint256{bigToUint256(div), bigToUint256(mod)}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	// the random inputs above rarely have small divisors.
+	f2 := func(a Uint256, b Uint128) [2]Uint256 {
+		return f(a, b.Uint256())
+	}
+	g2 := func(a Uint256, b Uint128) [2]Uint256 {
+		return g(a, b.Uint256())
+	}
+	if err := quick.CheckEqual(f2, g2, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint256_DivModPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic, but not")
+		}
+	}()
+	uint256Input.DivMod(Uint256{})
+}
+
+func BenchmarkUint256_DivMod(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		div, mod := uint256Input.DivMod(uint256Input)
+		runtime.KeepAlive(div)
+		runtime.KeepAlive(mod)
+	}
+}
+
+func TestUint256_CmpQuick(t *testing.T) {
+	f := func(a, b Uint256) int {
+		return a.Cmp(b)
+	}
+	g := func(a, b Uint256) int {
+		return uint256ToBig(nil, a).Cmp(uint256ToBig(nil, b))
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+	if err := quick.CheckEqual(func(a Uint256) int { return a.Cmp(a) }, func(a Uint256) int { return 0 }, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint256_BitsQuick(t *testing.T) {
+	f := func(a, b Uint256) [5]Uint256 {
+		return [5]Uint256{a.And(b), a.Or(b), a.Xor(b), a.AndNot(b), a.Not()}
+	}
+	g := func(a, b Uint256) [5]Uint256 {
+		bigA := uint256ToBig(nil, a)
+		bigB := uint256ToBig(nil, b)
+		return [5]Uint256{
+			bigToUint256(new(big.Int).And(bigA, bigB)),
+			bigToUint256(new(big.Int).Or(bigA, bigB)),
+			bigToUint256(new(big.Int).Xor(bigA, bigB)),
+			bigToUint256(new(big.Int).AndNot(bigA, bigB)),
+			bigToUint256(new(big.Int).Not(bigA)),
+		}
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint256_NegQuick(t *testing.T) {
+	f := func(a Uint256) Uint256 {
+		return a.Neg()
+	}
+	g := func(a Uint256) Uint256 {
+		return bigToUint256(new(big.Int).Neg(uint256ToBig(nil, a)))
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint256_ShiftQuick(t *testing.T) {
+	f := func(a Uint256, i uint16) [2]Uint256 {
+		n := uint(i % 300)
+		return [2]Uint256{a.Lsh(n), a.Rsh(n)}
+	}
+	g := func(a Uint256, i uint16) [2]Uint256 {
+		n := uint(i % 300)
+		bigA := uint256ToBig(nil, a)
+		return [2]Uint256{
+			bigToUint256(new(big.Int).Lsh(bigA, n)),
+			bigToUint256(new(big.Int).Rsh(bigA, n)),
+		}
+	}
+	if err := quick.CheckEqual(f, g, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkUint256_Lsh(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint256Input.Lsh(uint(i & 0xff)))
+	}
+}
+
+func BenchmarkUint256_Rsh(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint256Input.Rsh(uint(i & 0xff)))
+	}
+}
+
+func TestUint256_Len(t *testing.T) {
+	testCases := []struct {
+		a                      Uint256
+		len, leading, trailing int
+		ones                   int
+	}{
+		{Uint256{}, 0, 256, 256, 0},
+		{Uint256{Uint128{0, 0}, Uint128{0, 1}}, 1, 255, 0, 1},
+		{Uint256{Uint128{0, 1}, Uint128{0, 0}}, 129, 127, 128, 1},
+		{Uint256{Uint128{1 << 63, 0}, Uint128{0, 0}}, 256, 0, 255, 1},
+		{Uint256{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}}, 256, 0, 0, 256},
+	}
+
+	for i, tc := range testCases {
+		if got := tc.a.Len(); got != tc.len {
+			t.Errorf("%d: Len(%#v) should %d, but %d", i, tc.a, tc.len, got)
+		}
+		if got := tc.a.LeadingZeros(); got != tc.leading {
+			t.Errorf("%d: LeadingZeros(%#v) should %d, but %d", i, tc.a, tc.leading, got)
+		}
+		if got := tc.a.TrailingZeros(); got != tc.trailing {
+			t.Errorf("%d: TrailingZeros(%#v) should %d, but %d", i, tc.a, tc.trailing, got)
+		}
+		if got := tc.a.OnesCount(); got != tc.ones {
+			t.Errorf("%d: OnesCount(%#v) should %d, but %d", i, tc.a, tc.ones, got)
+		}
+	}
+}
+
+func TestUint256_RotateLeftQuick(t *testing.T) {
+	f := func(a Uint256, k int16) Uint256 {
+		return a.RotateLeft(int(k))
+	}
+	g := func(a Uint256, k int16) Uint256 {
+		n := uint(int(k)&255) % 256
+		return a.Lsh(n).Or(a.Rsh(256 - n))
+	}
+	if err := quick.CheckEqual(f, g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint256_Reverse(t *testing.T) {
+	a := Uint256{Uint128{0x0123_4567_89ab_cdef, 0x0011_2233_4455_6677}, Uint128{0x8899_aabb_ccdd_eeff, 0xfedc_ba98_7654_3210}}
+	if got, want := a.ReverseBytes(), (Uint256{Uint128{0x1032_5476_98ba_dcfe, 0xffee_ddcc_bbaa_9988}, Uint128{0x7766_5544_3322_1100, 0xefcd_ab89_6745_2301}}); got != want {
+		t.Errorf("ReverseBytes(%#v) should %#v, but %#v", a, want, got)
+	}
+	if got := a.Reverse().Reverse(); got != a {
+		t.Errorf("Reverse(Reverse(%#v)) should %#v, but %#v", a, a, got)
+	}
+	if got, want := (Uint256{L: Uint128{0, 1}}).Reverse(), (Uint256{H: Uint128{1 << 63, 0}}); got != want {
+		t.Errorf("Reverse(1) should %#v, but %#v", want, got)
+	}
+}
+
+func TestUint256_TextQuick(t *testing.T) {
+	for base := 2; base <= 36; base++ {
+		f := func(a Uint256) string {
+			return a.Text(base)
+		}
+		g := func(a Uint256) string {
+			return uint256ToBig(nil, a).Text(base)
+		}
+		if err := quick.CheckEqual(f, g, nil); err != nil {
+			t.Errorf("base %d: %v", base, err)
+		}
+	}
+}
+
+func TestUint256_String(t *testing.T) {
+	a := Uint256{Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}, Uint128{0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff}}
+	want := "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	if got := a.String(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if got := string(a.Append([]byte("x="), 10)); got != "x="+want {
+		t.Errorf("want %q, got %q", "x="+want, got)
+	}
+	if got := (Uint256{}).String(); got != "0" {
+		t.Errorf("want %q, got %q", "0", got)
+	}
+}
+
+func BenchmarkUint256_String(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runtime.KeepAlive(uint256Input.String())
+	}
+}
@@ -0,0 +1,181 @@
+package int128
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// Uint128FromBytes returns the Uint128 of the big-endian bytes b.
+// A UUID in its binary form can be converted by Uint128FromBytes,
+// and the order of the Uint128 values by Cmp matches the byte order of the UUIDs.
+func Uint128FromBytes(b [16]byte) Uint128 {
+	return Uint128{binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])}
+}
+
+// Bytes returns the big-endian bytes of a.
+func (a Uint128) Bytes() [16]byte {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], a.H)
+	binary.BigEndian.PutUint64(b[8:], a.L)
+	return b
+}
+
+var errInvalidUUID = errors.New("int128: invalid UUID format")
+
+var errUUIDv7Time = errors.New("int128: UUIDv7 timestamp out of range")
+
+// Uint128FromUUID parses the text representation of a UUID.
+// It accepts the standard form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx,
+// the form with the "urn:uuid:" prefix, the form enclosed in braces,
+// and 32 hex digits without hyphens.
+func Uint128FromUUID(s string) (Uint128, error) {
+	switch len(s) {
+	case 36:
+		// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+	case 36 + 9:
+		// urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+		if !equalFoldASCII(s[:9], "urn:uuid:") {
+			return Uint128{}, errInvalidUUID
+		}
+		s = s[9:]
+	case 36 + 2:
+		// {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
+		if s[0] != '{' || s[len(s)-1] != '}' {
+			return Uint128{}, errInvalidUUID
+		}
+		s = s[1 : len(s)-1]
+	case 32:
+		// xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+		return parseUUIDHex(s)
+	default:
+		return Uint128{}, errInvalidUUID
+	}
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return Uint128{}, errInvalidUUID
+	}
+	var buf [32]byte
+	copy(buf[0:8], s[0:8])
+	copy(buf[8:12], s[9:13])
+	copy(buf[12:16], s[14:18])
+	copy(buf[16:20], s[19:23])
+	copy(buf[20:32], s[24:36])
+	return parseUUIDHex(string(buf[:]))
+}
+
+// parseUUIDHex parses 32 hex digits.
+func parseUUIDHex(s string) (Uint128, error) {
+	var a Uint128
+	for i := 0; i < 32; i++ {
+		var d byte
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			d = c - '0'
+		case 'a' <= c && c <= 'f':
+			d = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			d = c - 'A' + 10
+		default:
+			return Uint128{}, errInvalidUUID
+		}
+		a.H = a.H<<4 | a.L>>60
+		a.L = a.L<<4 | uint64(d)
+	}
+	return a, nil
+}
+
+func equalFoldASCII(s, t string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != t[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UUIDString returns the standard text representation of a as a UUID,
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, in lower case.
+func (a Uint128) UUIDString() string {
+	const digits = "0123456789abcdef"
+	var buf [36]byte
+	j := len(buf)
+	for i := 0; i < 32; i++ {
+		if i == 12 || i == 16 || i == 20 || i == 24 {
+			j--
+			buf[j] = '-'
+		}
+		j--
+		buf[j] = digits[a.L&0xf]
+		a.L = a.L>>4 | a.H<<60
+		a.H >>= 4
+	}
+	return string(buf[:])
+}
+
+// UUIDVariant is the variant field of a UUID.
+type UUIDVariant byte
+
+// The variants of UUID defined in RFC 9562.
+const (
+	UUIDVariantNCS       UUIDVariant = iota // reserved, NCS backward compatibility
+	UUIDVariantRFC9562                      // the variant specified in RFC 9562
+	UUIDVariantMicrosoft                    // reserved, Microsoft Corporation backward compatibility
+	UUIDVariantFuture                       // reserved for future definition
+)
+
+// UUIDVariant returns the variant field of a as a UUID.
+func (a Uint128) UUIDVariant() UUIDVariant {
+	switch {
+	case a.L>>63 == 0b0:
+		return UUIDVariantNCS
+	case a.L>>62 == 0b10:
+		return UUIDVariantRFC9562
+	case a.L>>61 == 0b110:
+		return UUIDVariantMicrosoft
+	}
+	return UUIDVariantFuture
+}
+
+// UUIDVersion returns the version field of a as a UUID.
+// The version is meaningful only if the variant is UUIDVariantRFC9562.
+func (a Uint128) UUIDVersion() int {
+	return int(a.H>>12) & 0xf
+}
+
+// UUIDv7Time returns the timestamp of a as a UUIDv7.
+// The result has millisecond precision.
+// ok is false if a is not a UUIDv7.
+func (a Uint128) UUIDv7Time() (t time.Time, ok bool) {
+	if a.UUIDVariant() != UUIDVariantRFC9562 || a.UUIDVersion() != 7 {
+		return time.Time{}, false
+	}
+	ms := int64(a.H >> 16)
+	return time.UnixMilli(ms), true
+}
+
+// uuidV7Mask is the mask of the random bits of UUIDv7,
+// which are rand_a (12 bits) and rand_b (62 bits).
+var uuidV7Mask = Uint128{0x0000_0000_0000_0fff, 0x3fff_ffff_ffff_ffff}
+
+// NewUUIDv7 returns a new UUIDv7 with the timestamp t and the random bits read from r.
+// r is typically [crypto/rand.Reader].
+// The timestamp is truncated to the milliseconds since the Unix epoch.
+// It returns an error if t is before the epoch or doesn't fit in 48 bits of milliseconds,
+// which is after the year 10889.
+func NewUUIDv7(t time.Time, r io.Reader) (Uint128, error) {
+	if t.Before(time.Unix(0, 0)) || !t.Before(time.UnixMilli(1<<48)) {
+		return Uint128{}, errUUIDv7Time
+	}
+	v, err := RandUint128(r)
+	if err != nil {
+		return Uint128{}, err
+	}
+	ms := uint64(t.UnixMilli())
+	version := Uint128{ms<<16 | 0x7<<12, 0b10 << 62}
+	return v.And(uuidV7Mask).Or(version), nil
+}
@@ -0,0 +1,201 @@
+package int128
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+func TestUint128FromUUID(t *testing.T) {
+	want := Uint128{0x6ba7_b810_9dad_11d1, 0x80b4_00c0_4fd4_30c8}
+	testCases := []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"URN:UUID:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	}
+	for i, s := range testCases {
+		got, err := Uint128FromUUID(s)
+		if err != nil {
+			t.Errorf("%d: %q: unexpected error: %v", i, s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%d: %q should %#v, but %#v", i, s, want, got)
+		}
+	}
+}
+
+func TestUint128FromUUID_Error(t *testing.T) {
+	testCases := []string{
+		"",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b810x9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cg",
+		"urn:uuid+6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"(6ba7b810-9dad-11d1-80b4-00c04fd430c8)",
+		"6ba7b8109dad11d180b400c04fd430c-",
+	}
+	for i, s := range testCases {
+		if _, err := Uint128FromUUID(s); err == nil {
+			t.Errorf("%d: %q: want error, but not", i, s)
+		}
+	}
+}
+
+func TestUint128_UUIDStringQuick(t *testing.T) {
+	f := func(a Uint128) bool {
+		b, err := Uint128FromUUID(a.UUIDString())
+		return err == nil && a == b
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	a := Uint128{0x6ba7_b810_9dad_11d1, 0x80b4_00c0_4fd4_30c8}
+	if got, want := a.UUIDString(), "6ba7b810-9dad-11d1-80b4-00c04fd430c8"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestUint128_BytesQuick(t *testing.T) {
+	f := func(a Uint128) bool {
+		return Uint128FromBytes(a.Bytes()) == a
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	// Cmp matches the byte order of UUIDs.
+	g := func(a, b Uint128) bool {
+		x, y := a.Bytes(), b.Bytes()
+		return a.Cmp(b) == bytes.Compare(x[:], y[:])
+	}
+	if err := quick.Check(g, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUint128_UUIDVersion(t *testing.T) {
+	testCases := []struct {
+		uuid    string
+		version int
+		variant UUIDVariant
+	}{
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", 1, UUIDVariantRFC9562},
+		{"00000000-0000-4000-bfff-000000000000", 4, UUIDVariantRFC9562},
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7, UUIDVariantRFC9562},
+		{"00000000-0000-0000-0000-000000000000", 0, UUIDVariantNCS},
+		{"00000000-0000-0000-c000-000000000000", 0, UUIDVariantMicrosoft},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", 15, UUIDVariantFuture},
+	}
+	for i, tc := range testCases {
+		a, err := Uint128FromUUID(tc.uuid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := a.UUIDVersion(); got != tc.version {
+			t.Errorf("%d: the version of %s should %d, but %d", i, tc.uuid, tc.version, got)
+		}
+		if got := a.UUIDVariant(); got != tc.variant {
+			t.Errorf("%d: the variant of %s should %d, but %d", i, tc.uuid, tc.variant, got)
+		}
+	}
+}
+
+func TestUint128_UUIDv7Time(t *testing.T) {
+	// the example of RFC 9562 Appendix A.6.
+	a, err := Uint128FromUUID("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := a.UUIDv7Time()
+	if !ok {
+		t.Fatal("want ok, but not")
+	}
+	if want := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	b, _ := Uint128FromUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if _, ok := b.UUIDv7Time(); ok {
+		t.Error("UUIDv1 is not UUIDv7")
+	}
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	now := time.Date(2024, time.May, 1, 12, 34, 56, 789_000_000, time.UTC)
+
+	var prev Uint128
+	for i := 0; i < 100; i++ {
+		ts := now.Add(time.Duration(i) * time.Millisecond)
+		a, err := NewUUIDv7(ts, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.UUIDVersion() != 7 || a.UUIDVariant() != UUIDVariantRFC9562 {
+			t.Errorf("%s is not UUIDv7", a.UUIDString())
+		}
+		got, ok := a.UUIDv7Time()
+		if !ok || !got.Equal(ts) {
+			t.Errorf("the timestamp of %s should %v, but %v", a.UUIDString(), ts, got)
+		}
+
+		// UUIDv7 is sortable by the time.
+		if i > 0 && prev.Cmp(a) >= 0 {
+			t.Errorf("%s should be less than %s", prev.UUIDString(), a.UUIDString())
+		}
+		prev = a
+	}
+}
+
+func TestNewUUIDv7_OutOfRange(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	testCases := []time.Time{
+		time.Unix(0, -1),
+		time.Date(1969, time.December, 31, 23, 59, 59, 0, time.UTC),
+		time.UnixMilli(1 << 48),
+		time.Date(10890, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(300_000_000, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, ts := range testCases {
+		if a, err := NewUUIDv7(ts, r); err != errUUIDv7Time {
+			t.Errorf("NewUUIDv7(%v) should be error, but %s, %v", ts, a.UUIDString(), err)
+		}
+	}
+
+	// the boundaries
+	testCases = []time.Time{
+		time.Unix(0, 0),
+		time.UnixMilli(1<<48 - 1),
+		time.UnixMilli(1<<48 - 1).Add(time.Millisecond - 1),
+	}
+	for _, ts := range testCases {
+		a, err := NewUUIDv7(ts, r)
+		if err != nil {
+			t.Errorf("NewUUIDv7(%v): unexpected error: %v", ts, err)
+			continue
+		}
+		got, _ := a.UUIDv7Time()
+		if want := ts.Truncate(time.Millisecond); !got.Equal(want) {
+			t.Errorf("the timestamp of %s should %v, but %v", a.UUIDString(), want, got)
+		}
+	}
+}
+
+func BenchmarkUint128FromUUID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Uint128FromUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	}
+}
+
+func BenchmarkUint128_UUIDString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = uint128Input.UUIDString()
+	}
+}